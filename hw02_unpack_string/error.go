@@ -0,0 +1,52 @@
+package hw02unpackstring
+
+import "fmt"
+
+// Reason - причина, по которой строка признана некорректной.
+type Reason int
+
+const (
+	// ReasonLeadingDigit - строка начинается с цифры: "3abc".
+	ReasonLeadingDigit Reason = iota + 1
+	// ReasonConsecutiveDigits - число вместо цифры в строгом режиме: "aaa10b".
+	ReasonConsecutiveDigits
+	// ReasonInvalidEscape - экранируется что-то, кроме цифры или слэша: `qw\ne`.
+	ReasonInvalidEscape
+	// ReasonDanglingEscape - строка оканчивается на незакрытый слэш: `abc\`.
+	ReasonDanglingEscape
+)
+
+// String возвращает описание причины для сообщения об ошибке.
+func (r Reason) String() string {
+	switch r {
+	case ReasonLeadingDigit:
+		return "leading digit"
+	case ReasonConsecutiveDigits:
+		return "consecutive digits"
+	case ReasonInvalidEscape:
+		return "invalid escape"
+	case ReasonDanglingEscape:
+		return "dangling escape"
+	default:
+		return fmt.Sprintf("Reason(%d)", int(r))
+	}
+}
+
+// UnpackError описывает, где и почему строка некорректна.
+// Оборачивает ErrInvalidString, поэтому errors.Is(err, ErrInvalidString) продолжает работать.
+type UnpackError struct {
+	RuneIndex int    // номер некорректного символа в рунах
+	Offset    int    // смещение некорректного символа в байтах
+	Rune      rune   // некорректный символ
+	Reason    Reason // причина ошибки
+}
+
+func (e *UnpackError) Error() string {
+	return fmt.Sprintf("%s: %s %q at rune %d (byte offset %d)",
+		ErrInvalidString, e.Reason, e.Rune, e.RuneIndex, e.Offset)
+}
+
+// Unwrap позволяет errors.Is находить ErrInvalidString.
+func (e *UnpackError) Unwrap() error {
+	return ErrInvalidString
+}
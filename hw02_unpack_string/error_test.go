@@ -0,0 +1,52 @@
+package hw02unpackstring
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackError(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected UnpackError
+	}{
+		{input: "3abc", expected: UnpackError{RuneIndex: 0, Offset: 0, Rune: '3', Reason: ReasonLeadingDigit}},
+		{input: "45", expected: UnpackError{RuneIndex: 0, Offset: 0, Rune: '4', Reason: ReasonLeadingDigit}},
+		{input: "aaa10b", expected: UnpackError{RuneIndex: 4, Offset: 4, Rune: '0', Reason: ReasonConsecutiveDigits}},
+		{input: "пп12", expected: UnpackError{RuneIndex: 3, Offset: 5, Rune: '2', Reason: ReasonConsecutiveDigits}},
+		{input: `\456`, expected: UnpackError{RuneIndex: 3, Offset: 3, Rune: '6', Reason: ReasonConsecutiveDigits}},
+		{input: `qw\ne`, expected: UnpackError{RuneIndex: 3, Offset: 3, Rune: 'n', Reason: ReasonInvalidEscape}},
+		{input: `п\ё`, expected: UnpackError{RuneIndex: 2, Offset: 3, Rune: 'ё', Reason: ReasonInvalidEscape}},
+		{input: `abc\`, expected: UnpackError{RuneIndex: 3, Offset: 3, Rune: '\\', Reason: ReasonDanglingEscape}},
+		{input: `пп\`, expected: UnpackError{RuneIndex: 2, Offset: 4, Rune: '\\', Reason: ReasonDanglingEscape}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Unpack(tc.input)
+			require.Truef(t, errors.Is(err, ErrInvalidString), "actual error %q", err)
+
+			var unpackErr *UnpackError
+			require.True(t, errors.As(err, &unpackErr))
+			require.Equal(t, tc.expected, *unpackErr)
+
+			// Потоковая распаковка сообщает ту же позицию
+			streamErr := UnpackStream(strings.NewReader(tc.input), &strings.Builder{})
+			require.Equal(t, err, streamErr)
+		})
+	}
+}
+
+func TestUnpackErrorMessage(t *testing.T) {
+	_, err := Unpack("aaa10b")
+	require.EqualError(t, err, `invalid string: consecutive digits '0' at rune 4 (byte offset 4)`)
+}
+
+func TestReasonString(t *testing.T) {
+	require.Equal(t, "dangling escape", ReasonDanglingEscape.String())
+	require.Equal(t, "Reason(0)", Reason(0).String())
+}
@@ -0,0 +1,87 @@
+package hw02unpackstring
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackGraphemes(t *testing.T) {
+	const (
+		eAcute      = "e\u0301"                                           // e + комбинируемое ударение
+		thumbsUp    = "\U0001F44D\U0001F3FD"                              // эмодзи + модификатор тона кожи
+		family      = "\U0001F468\u200D\U0001F469\u200D\U0001F467"        // ZWJ-последовательность
+		flagRU      = "\U0001F1F7\U0001F1FA"                              // пара региональных индикаторов
+		flagDE      = "\U0001F1E9\U0001F1EA"                              // пара региональных индикаторов
+		keycapOne   = "1\uFE0F\u20E3"                                     // цифра + VS16 + keycap
+		hangulSyll  = "\u1100\u1161\u11A8"                                // слог хангыль из чамо
+		zalgoLetter = "a\u0300\u0301\u0302\u0303\u0304\u0305\u0306\u0307" // много комбинируемых знаков
+	)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "combining mark", input: eAcute + "3", expected: strings.Repeat(eAcute, 3)},
+		{name: "combining mark removed by zero", input: "a" + eAcute + "0b", expected: "ab"},
+		{name: "many combining marks", input: zalgoLetter + "2", expected: strings.Repeat(zalgoLetter, 2)},
+		{name: "skin tone modifier", input: thumbsUp + "2", expected: strings.Repeat(thumbsUp, 2)},
+		{name: "zwj sequence", input: family + "3", expected: strings.Repeat(family, 3)},
+		{name: "flag", input: flagRU + "2", expected: strings.Repeat(flagRU, 2)},
+		{name: "adjacent flags", input: flagRU + flagDE + "2", expected: flagRU + strings.Repeat(flagDE, 2)},
+		{name: "escaped keycap", input: `\` + keycapOne + "2", expected: strings.Repeat(keycapOne, 2)},
+		{name: "hangul jamo", input: hangulSyll + "2", expected: strings.Repeat(hangulSyll, 2)},
+		{name: "crlf", input: "\r\n2", expected: "\r\n\r\n"},
+		{name: "plain runes", input: "a4bc2d5e", expected: "aaaabccddddde"},
+		{name: "mark after count starts new cluster", input: "e2\u0301", expected: "ee\u0301"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := UnpackWithOptions(tc.input, Options{Graphemes: true})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+
+			var out bytes.Buffer
+			err = UnpackStreamWithOptions(strings.NewReader(tc.input), &out, Options{Graphemes: true})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestUnpackGraphemesLongCluster(t *testing.T) {
+	// Буква с десятками тысяч комбинируемых знаков: графема делится на части,
+	// поэтому время и память не растут с её длиной
+	input := "a" + strings.Repeat("\u0301", 40_000)
+
+	result, err := UnpackWithOptions(input+"2", Options{Graphemes: true})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(result, input))
+	repeated := result[len(input):]
+	require.NotEmpty(t, repeated)
+	require.LessOrEqual(t, len(repeated), maxGraphemeLen)
+	require.True(t, strings.HasSuffix(input, repeated))
+
+	var out bytes.Buffer
+	err = UnpackStreamWithOptions(strings.NewReader(input+"2"), &out, Options{Graphemes: true})
+	require.NoError(t, err)
+	require.Equal(t, result, out.String())
+}
+
+func TestUnpackGraphemesWithMultiDigit(t *testing.T) {
+	result, err := UnpackWithOptions("e\u030112", Options{Graphemes: true, MultiDigitCounts: true})
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("e\u0301", 12), result)
+}
+
+func TestUnpackWithoutGraphemes(t *testing.T) {
+	// Без режима Graphemes повторяется только последняя руна
+	result, err := Unpack("e\u03013")
+	require.NoError(t, err)
+	require.Equal(t, "e\u0301\u0301\u0301", result)
+}
@@ -0,0 +1,46 @@
+package hw02unpackstring
+
+import "strings"
+
+// Options настраивает распаковку в UnpackWithOptions.
+// Нулевое значение соответствует строгому режиму Unpack.
+type Options struct {
+	// MultiDigitCounts разрешает многозначные счётчики повторений: "a10b" => "aaaaaaaaaab".
+	// В строгом режиме такая строка некорректна.
+	MultiDigitCounts bool
+
+	// MaxOutputLen ограничивает длину результата в байтах, защищая от строк вида "a999999999".
+	// При превышении возвращается ErrOutputTooLarge. Значение <= 0 снимает ограничение.
+	MaxOutputLen int
+
+	// Graphemes применяет счётчик ко всей предшествующей графеме (grapheme cluster), а не к последней руне:
+	// "e\u03013" => "e\u0301e\u0301e\u0301", а не "e\u0301\u0301\u0301".
+	// Графемы длиннее 256 байт делятся на части, счётчик применяется к последней.
+	Graphemes bool
+}
+
+// UnpackWithOptions распаковывает строку с заданными настройками.
+// Правила экранирования и проверки корректности те же, что у Unpack.
+func UnpackWithOptions(s string, opts Options) (string, error) {
+	// Пустая строка - валидный случай, возвращаем пустую строку
+	if s == "" {
+		return "", nil
+	}
+
+	var result strings.Builder
+	u := newUnpacker(&result, opts)
+
+	// Проходим по всем рунам строки, offset - смещение руны в байтах
+	for offset, r := range s {
+		if err := u.processRune(r, offset); err != nil {
+			return "", err
+		}
+	}
+
+	if err := u.finish(); err != nil {
+		return "", err
+	}
+
+	// Возвращаем собранную строку
+	return result.String(), nil
+}
@@ -0,0 +1,95 @@
+package hw02unpackstring
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackWithOptionsMultiDigit(t *testing.T) {
+	opts := Options{MultiDigitCounts: true}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "aaa10b", expected: "aa" + strings.Repeat("a", 10) + "b"},
+		{input: "a4bc2d5e", expected: "aaaabccddddde"},
+		{input: "a12", expected: strings.Repeat("a", 12)},
+		{input: "a00b", expected: "b"},
+		{input: "a011", expected: strings.Repeat("a", 11)},
+		{input: "п15", expected: strings.Repeat("п", 15)},
+		{input: `\123`, expected: strings.Repeat("1", 23)},
+		{input: `a11\1`, expected: strings.Repeat("a", 11) + "1"},
+		{input: `\\10`, expected: strings.Repeat(`\`, 10)},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			result, err := UnpackWithOptions(tc.input, opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+
+			var out bytes.Buffer
+			require.NoError(t, UnpackStreamWithOptions(strings.NewReader(tc.input), &out, opts))
+			require.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestUnpackWithOptionsInvalidString(t *testing.T) {
+	opts := Options{MultiDigitCounts: true}
+
+	for _, input := range []string{"10a", "1", `a\b`, `a10\`} {
+		input := input
+		t.Run(input, func(t *testing.T) {
+			_, err := UnpackWithOptions(input, opts)
+			require.Truef(t, errors.Is(err, ErrInvalidString), "actual error %q", err)
+		})
+	}
+}
+
+func TestUnpackWithOptionsMaxOutputLen(t *testing.T) {
+	t.Run("limit reached exactly", func(t *testing.T) {
+		result, err := UnpackWithOptions("a5b", Options{MaxOutputLen: 6})
+		require.NoError(t, err)
+		require.Equal(t, "aaaaab", result)
+	})
+
+	t.Run("limit exceeded by count", func(t *testing.T) {
+		_, err := UnpackWithOptions("a999999999", Options{MultiDigitCounts: true, MaxOutputLen: 1 << 20})
+		require.Truef(t, errors.Is(err, ErrOutputTooLarge), "actual error %q", err)
+		require.False(t, errors.Is(err, ErrInvalidString))
+	})
+
+	t.Run("limit exceeded by single char", func(t *testing.T) {
+		_, err := UnpackWithOptions("a5b", Options{MaxOutputLen: 5})
+		require.Truef(t, errors.Is(err, ErrOutputTooLarge), "actual error %q", err)
+	})
+
+	t.Run("limit counted in bytes", func(t *testing.T) {
+		_, err := UnpackWithOptions("п3", Options{MaxOutputLen: 5})
+		require.Truef(t, errors.Is(err, ErrOutputTooLarge), "actual error %q", err)
+	})
+
+	t.Run("count overflows int", func(t *testing.T) {
+		_, err := UnpackWithOptions("a99999999999999999999", Options{MultiDigitCounts: true})
+		require.Truef(t, errors.Is(err, ErrOutputTooLarge), "actual error %q", err)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		err := UnpackStreamWithOptions(strings.NewReader("a999999999"), &bytes.Buffer{},
+			Options{MultiDigitCounts: true, MaxOutputLen: 1 << 20})
+		require.Truef(t, errors.Is(err, ErrOutputTooLarge), "actual error %q", err)
+	})
+}
+
+func TestUnpackStrictModeUnchanged(t *testing.T) {
+	// Без опций многозначные счётчики по-прежнему некорректны
+	_, err := UnpackWithOptions("aaa10b", Options{MaxOutputLen: 100})
+	require.Truef(t, errors.Is(err, ErrInvalidString), "actual error %q", err)
+}
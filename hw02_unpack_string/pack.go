@@ -0,0 +1,77 @@
+package hw02unpackstring
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 возвращается при попытке упаковать строку, не являющуюся корректной UTF-8 строкой.
+var ErrInvalidUTF8 = errors.New("invalid utf-8 string")
+
+// maxCount - максимальный счётчик повторений, который можно записать одной цифрой.
+const maxCount = 9
+
+// Pack выполняет обратное к Unpack преобразование - сжимает повторяющиеся подряд символы.
+// Результат всегда является корректной для Unpack строкой, и Unpack(Pack(s)) == s.
+// Серии длиннее 9 символов разбиваются на несколько частей, цифры и слэши экранируются.
+func Pack(s string) (string, error) {
+	// Пустая строка - валидный случай, возвращаем пустую строку
+	if s == "" {
+		return "", nil
+	}
+
+	// Некорректную UTF-8 строку нельзя восстановить через []rune
+	if !utf8.ValidString(s) {
+		return "", ErrInvalidUTF8
+	}
+
+	runes := []rune(s)
+
+	var result strings.Builder
+	// Без экранирования упакованная строка не длиннее исходной
+	result.Grow(len(s))
+
+	// Проходим по сериям одинаковых рун
+	for i := 0; i < len(runes); {
+		// Ищем конец текущей серии
+		j := i + 1
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+
+		writeRun(runes[i], j-i, &result)
+		i = j
+	}
+
+	return result.String(), nil
+}
+
+// writeRun записывает серию из count одинаковых символов r.
+// result - указатель на strings.Builder, чтобы изменять оригинальный объект, а не его копию.
+func writeRun(r rune, count int, result *strings.Builder) {
+	// Полные части серии записываем с максимальным счётчиком
+	for ; count >= maxCount; count -= maxCount {
+		writePackedRune(r, result)
+		result.WriteString(strconv.Itoa(maxCount))
+	}
+
+	switch {
+	case count == 1:
+		// Одиночный символ записываем без счётчика
+		writePackedRune(r, result)
+	case count > 1:
+		writePackedRune(r, result)
+		result.WriteString(strconv.Itoa(count))
+	}
+}
+
+// writePackedRune записывает символ, экранируя его при необходимости,
+// чтобы Unpack не принял его за счётчик или начало экранирования.
+func writePackedRune(r rune, result *strings.Builder) {
+	if isEscapable(r) {
+		result.WriteRune(escapeRune)
+	}
+	result.WriteRune(r)
+}
@@ -0,0 +1,86 @@
+package hw02unpackstring
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/quick"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPack(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "empty", input: "", expected: ""},
+		{name: "no repeats", input: "abcd", expected: "abcd"},
+		{name: "repeats", input: "aaaabccddddde", expected: "a4bc2d5e"},
+		{name: "exactly nine", input: strings.Repeat("a", 9), expected: "a9"},
+		{name: "ten splits into nine and one", input: strings.Repeat("a", 10), expected: "a9a"},
+		{name: "twenty splits into nine, nine and two", input: strings.Repeat("a", 20), expected: "a9a9a2"},
+		{name: "digits escaped", input: "qwe45", expected: `qwe\4\5`},
+		{name: "repeated digit escaped", input: "qwe44444", expected: `qwe\45`},
+		{name: "slashes escaped", input: `qwe\\\\\`, expected: `qwe\\5`},
+		{name: "slash and digit", input: `qwe\3`, expected: `qwe\\\3`},
+		{name: "unicode", input: "ппрррив", expected: "п2р3ив"},
+		{name: "newline", input: "d\n\n\n\n\nabc", expected: "d\n5abc"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Pack(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestPackInvalidUTF8(t *testing.T) {
+	_, err := Pack("a\xffb")
+	require.Truef(t, errors.Is(err, ErrInvalidUTF8), "actual error %q", err)
+}
+
+// Свойство: распаковка упакованной строки возвращает исходную строку.
+func TestPackUnpackRoundTrip(t *testing.T) {
+	roundTrip := func(s string) bool {
+		packed, err := Pack(s)
+		if err != nil {
+			return false
+		}
+		unpacked, err := Unpack(packed)
+		return err == nil && unpacked == s
+	}
+
+	// Случайные строки плохо покрывают серии, поэтому дополнительно проверяем строки из повторов
+	repeated := func(r rune, n uint8) bool {
+		if !utf8.ValidRune(r) {
+			return true
+		}
+		return roundTrip(strings.Repeat(string(r), int(n)) + "1" + strings.Repeat(`\`, int(n)))
+	}
+
+	require.NoError(t, quick.Check(roundTrip, nil))
+	require.NoError(t, quick.Check(repeated, nil))
+}
+
+func FuzzPackUnpack(f *testing.F) {
+	for _, seed := range []string{"", "a", "aaaaaaaaaaaa", "qwe45", `qwe\\\\\`, "d\n\n\n\n\nabc", "😀😀🎉"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip()
+		}
+		packed, err := Pack(s)
+		require.NoError(t, err)
+		unpacked, err := Unpack(packed)
+		require.NoError(t, err)
+		require.Equal(t, s, unpacked)
+	})
+}
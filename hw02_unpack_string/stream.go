@@ -0,0 +1,48 @@
+package hw02unpackstring
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// UnpackStream распаковывает данные из r и записывает результат в w.
+// В отличие от Unpack не держит в памяти ни вход, ни результат целиком:
+// данные читаются и декодируются посимвольно через буферы фиксированного размера.
+// Правила проверки совпадают с Unpack; при некорректных данных возвращается *UnpackError
+// со смещением некорректного символа от начала потока.
+// При ошибке часть результата может быть уже записана в w.
+func UnpackStream(r io.Reader, w io.Writer) error {
+	return UnpackStreamWithOptions(r, w, Options{})
+}
+
+// UnpackStreamWithOptions - потоковая распаковка с настройками, как у UnpackWithOptions.
+func UnpackStreamWithOptions(r io.Reader, w io.Writer, opts Options) error {
+	reader := bufio.NewReader(r)
+	writer := bufio.NewWriter(w)
+	u := newUnpacker(writer, opts)
+
+	// offset - смещение текущей руны от начала потока в байтах
+	offset := 0
+	for {
+		currentRune, size, err := reader.ReadRune()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := u.processRune(currentRune, offset); err != nil {
+			return err
+		}
+		offset += size
+	}
+
+	if err := u.finish(); err != nil {
+		return err
+	}
+
+	// Сбрасываем в w остаток буфера
+	return writer.Flush()
+}
@@ -0,0 +1,101 @@
+package hw02unpackstring
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackStream(t *testing.T) {
+	inputs := []string{
+		"a4bc2d5e", "abccd", "", "aaa0b", "d\n5abc", "п2р3и1в", "😀2🎉3",
+		`qwe\4\5`, `qwe\45`, `qwe\\5`, `qwe\\\3`,
+	}
+
+	for _, input := range inputs {
+		input := input
+		t.Run(input, func(t *testing.T) {
+			expected, err := Unpack(input)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			// Читаем по одному байту, чтобы руны разрывались между чтениями
+			err = UnpackStream(iotest.OneByteReader(strings.NewReader(input)), &out)
+			require.NoError(t, err)
+			require.Equal(t, expected, out.String())
+		})
+	}
+}
+
+func TestUnpackStreamInvalidString(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset string
+	}{
+		{input: "3abc", offset: "byte offset 0"},
+		{input: "aaa10b", offset: "byte offset 4"},
+		{input: "пп12", offset: "byte offset 5"},
+		{input: `qw\ne`, offset: "byte offset 3"},
+		{input: `abc\`, offset: "byte offset 3"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			err := UnpackStream(strings.NewReader(tc.input), io.Discard)
+			require.Truef(t, errors.Is(err, ErrInvalidString), "actual error %q", err)
+			require.Contains(t, err.Error(), tc.offset)
+
+			// Unpack сообщает ту же ошибку
+			_, unpackErr := Unpack(tc.input)
+			require.Equal(t, err.Error(), unpackErr.Error())
+		})
+	}
+}
+
+func TestUnpackStreamReadError(t *testing.T) {
+	readErr := errors.New("read failed")
+	err := UnpackStream(iotest.ErrReader(readErr), io.Discard)
+	require.ErrorIs(t, err, readErr)
+}
+
+// errWriter - приёмник, всегда возвращающий ошибку.
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func TestUnpackStreamWriteError(t *testing.T) {
+	writeErr := errors.New("write failed")
+	err := UnpackStream(strings.NewReader("a4bc2"), errWriter{err: writeErr})
+	require.ErrorIs(t, err, writeErr)
+}
+
+func TestUnpackStreamLargeInput(t *testing.T) {
+	// Результат (11 МБ) не собирается в памяти, а сразу уходит в приёмник
+	const chunks = 1 << 20
+	input := strings.NewReader(strings.Repeat(`ab2c3\45`, chunks))
+
+	counter := &countingWriter{}
+	err := UnpackStream(input, counter)
+	require.NoError(t, err)
+	require.Equal(t, int64(len("abbccc44444")*chunks), counter.n)
+}
+
+// countingWriter считает записанные байты и отбрасывает данные.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -2,40 +2,70 @@ package hw02unpackstring
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
 )
 
 // ErrInvalidString возвращается при попытке распаковать некорректную строку.
-// Некорректными считаются строки, начинающиеся с цифры или содержащие числа (несколько цифр подряд).
+// Некорректными считаются строки, начинающиеся с цифры, содержащие числа (несколько цифр подряд),
+// а также строки с экранированием чего-то, кроме цифры или слэша, и строки, оканчивающиеся на `\`.
+// Место и причина ошибки доступны через errors.As с *UnpackError.
 var ErrInvalidString = errors.New("invalid string")
 
+// ErrOutputTooLarge возвращается, если распакованная строка превышает Options.MaxOutputLen
+// или счётчик повторений не помещается в int.
+var ErrOutputTooLarge = errors.New("output too large")
+
+// escapeRune - символ экранирования.
+const escapeRune = '\\'
+
+// repeatChunk - сколько повторений символа записывается за одну запись,
+// чтобы большие счётчики не требовали выделения памяти под весь результат.
+const repeatChunk = 1024
+
+// maxGraphemeLen - наибольшая длина графемы в байтах в режиме Graphemes.
+// Длиннее бывают только искусственные графемы, например буква с тысячами комбинируемых знаков:
+// такая графема делится на части, и счётчик применяется к последней из них.
+// Ограничение держит постоянными память потоковой распаковки и время поиска границы графемы.
+const maxGraphemeLen = 256
+
+// Unpack распаковывает строку в строгом режиме: счётчик повторений - одна цифра,
+// длина результата не ограничена.
 func Unpack(s string) (string, error) {
-	// Пустая строка - валидный случай, возвращаем пустую строку
-	if s == "" {
-		return "", nil
-	}
+	return UnpackWithOptions(s, Options{})
+}
 
-	// Преобразуем строку в массив рун для корректной работы с Unicode
-	runes := []rune(s)
-
-	var result strings.Builder
-
-	// Проходим по всем рунам строки
-	for i := 0; i < len(runes); i++ {
-		if isDigit(runes[i]) {
-			// Текущий символ - цифра, обрабатываем как счётчик повторений
-			if err := processDigit(runes, i, &result); err != nil {
-				return "", err
-			}
-		} else {
-			// Текущий символ - не цифра, обрабатываем как обычный символ
-			// processCharacter может вернуть i+1, если следующий символ - цифра
-			i = processCharacter(runes, i, &result)
-		}
-	}
+// unpacker распаковывает строку посимвольно, храня только состояние разбора.
+// Используется и для распаковки строки целиком, и для потоковой распаковки,
+// поэтому правила проверки корректности у них общие.
+// Результат записывается в io.StringWriter, которому удовлетворяют strings.Builder и bufio.Writer.
+type unpacker struct {
+	out     io.StringWriter
+	opts    Options
+	written int // сколько байт результата уже записано
+
+	runeIndex int // номер текущего символа в рунах
+
+	pending       string // символ (или графема в режиме Graphemes), за которым может следовать счётчик
+	hasPending    bool   // есть ли символ, ожидающий счётчика
+	pendingOffset int    // смещение ожидающего символа в байтах
+
+	counting    bool // после ожидающего символа уже началось число (только для MultiDigitCounts)
+	count       int  // накопленный счётчик повторений
+	countOffset int  // смещение первой цифры счётчика в байтах
+
+	escaped      bool // предыдущий символ - слэш, текущий нужно взять как есть
+	escapeIndex  int  // номер слэша в рунах для сообщения об ошибке
+	escapeOffset int  // смещение слэша в байтах для сообщения об ошибке
+}
 
-	// Возвращаем собранную строку
-	return result.String(), nil
+func newUnpacker(out io.StringWriter, opts Options) *unpacker {
+	return &unpacker{out: out, opts: opts}
 }
 
 // isDigit проверяет, является ли руна цифрой.
@@ -43,59 +73,179 @@ func isDigit(r rune) bool {
 	return r >= '0' && r <= '9'
 }
 
-// processDigit обрабатывает цифру - повторяет предыдущий символ указанное количество раз.
-// result - указатель на strings.Builder, чтобы изменять оригинальный объект, а не его копию.
-// Возвращает ошибку, если цифра стоит в начале строки или после другой цифры.
-func processDigit(runes []rune, i int, result *strings.Builder) error {
-	// Проверяем, что цифра не в начале строки
-	if i == 0 {
-		return ErrInvalidString
-	}
+// isEscapable проверяет, можно ли экранировать руну: экранируются только цифры и слэш.
+func isEscapable(r rune) bool {
+	return isDigit(r) || r == escapeRune
+}
 
-	// Получаем предыдущий символ
-	prevRune := runes[i-1]
+// tooLargeAt оборачивает ErrOutputTooLarge, добавляя смещение ошибки в байтах.
+func tooLargeAt(offset int) error {
+	return fmt.Errorf("%w: at byte offset %d", ErrOutputTooLarge, offset)
+}
 
-	// Проверяем, что предыдущий символ не цифра
-	if isDigit(prevRune) {
-		return ErrInvalidString
+// processRune обрабатывает очередную руну строки, offset - её смещение в байтах.
+func (u *unpacker) processRune(r rune, offset int) error {
+	defer func() { u.runeIndex++ }()
+
+	switch {
+	case u.escaped:
+		// Предыдущий символ - слэш, текущий символ нужно взять как есть
+		return u.processEscaped(r, offset)
+	case r == escapeRune:
+		// Текущий символ - слэш, запоминаем его и ждём экранируемый символ
+		u.escaped = true
+		u.escapeIndex = u.runeIndex
+		u.escapeOffset = offset
+		return nil
+	case isDigit(r):
+		// Текущий символ - цифра, обрабатываем как счётчик повторений
+		return u.processDigit(r, offset)
+	default:
+		// Текущий символ - не цифра, обрабатываем как обычный символ
+		return u.processCharacter(r, offset)
 	}
+}
 
+// processDigit обрабатывает цифру счётчика повторений ожидающего символа.
+// В строгом режиме символ сразу повторяется указанное количество раз,
+// в режиме MultiDigitCounts цифры накапливаются до следующего символа.
+// Возвращает ошибку, если цифра стоит в начале строки или после другой цифры (в строгом режиме).
+func (u *unpacker) processDigit(r rune, offset int) error {
 	// Преобразуем руну-цифру в число
-	count := int(runes[i] - '0')
+	digit := int(r - '0')
+
+	// Продолжение многозначного счётчика
+	if u.counting {
+		// Проверяем, что count*10 + digit не переполнит int
+		if u.count > (math.MaxInt-digit)/10 {
+			return tooLargeAt(u.countOffset)
+		}
+		u.count = u.count*10 + digit
+		return nil
+	}
 
-	// Повторяем предыдущий символ count раз (минус 1, так как он уже добавлен)
-	if count > 1 {
-		// strings.Repeat создаёт строку из count-1 повторений символа prevRune
-		result.WriteString(strings.Repeat(string(prevRune), count-1))
+	// Цифре не к чему относиться: она в начале строки или сразу после другого счётчика
+	if !u.hasPending {
+		reason := ReasonConsecutiveDigits
+		if u.runeIndex == 0 {
+			reason = ReasonLeadingDigit
+		}
+		return &UnpackError{RuneIndex: u.runeIndex, Offset: offset, Rune: r, Reason: reason}
+	}
+
+	u.counting = true
+	u.count = digit
+	u.countOffset = offset
+
+	// В строгом режиме счётчик из одной цифры, применяем его сразу
+	if !u.opts.MultiDigitCounts {
+		return u.flushPending()
 	}
 	return nil
 }
 
+// processEscaped обрабатывает символ после слэша - он добавляется как обычный,
+// даже если это цифра или другой слэш.
+// Возвращает ошибку, если экранируется что-то, кроме цифры или слэша.
+func (u *unpacker) processEscaped(r rune, offset int) error {
+	if !isEscapable(r) {
+		return &UnpackError{RuneIndex: u.runeIndex, Offset: offset, Rune: r, Reason: ReasonInvalidEscape}
+	}
+
+	u.escaped = false
+	// Экранированный символ обрабатываем как обычный:
+	// за ним тоже может стоять счётчик повторений
+	return u.processCharacter(r, u.escapeOffset)
+}
+
 // processCharacter обрабатывает обычный символ (не цифру).
-// result - указатель на strings.Builder, чтобы изменять оригинальный объект, а не его копию.
-// Возвращает новую позицию для итератора (i или i+1).
-func processCharacter(runes []rune, i int, result *strings.Builder) int {
-	currentRune := runes[i]
-
-	// Проверяем, есть ли следующий символ и является ли он цифрой
-	// Если да, то текущий символ нужно повторить указанное количество раз
-	if i+1 < len(runes) && isDigit(runes[i+1]) {
-		// Преобразуем следующую руну-цифру в число
-		count := int(runes[i+1] - '0')
-
-		// Добавляем текущий символ count раз
-		// strings.Repeat создаёт строку из count повторений символа currentRune
-		if count > 0 {
-			result.WriteString(strings.Repeat(string(currentRune), count))
-		}
+// Предыдущий ожидающий символ добавляется в результат (со счётчиком, если он был),
+// а текущий символ становится ожидающим.
+// В режиме Graphemes символ, продолжающий графему ожидающего символа, дописывается к ней.
+func (u *unpacker) processCharacter(r rune, offset int) error {
+	if u.extendsPending(r) {
+		u.pending += string(r)
+		return nil
+	}
+
+	if err := u.flushPending(); err != nil {
+		return err
+	}
+
+	u.pending = string(r)
+	u.hasPending = true
+	u.pendingOffset = offset
+	return nil
+}
+
+// extendsPending проверяет, что в режиме Graphemes символ r продолжает графему ожидающего символа:
+// например, комбинируемое ударение после буквы или модификатор тона кожи после эмодзи.
+// После начавшегося счётчика графема уже завершена, а графема длиной maxGraphemeLen больше не растёт.
+func (u *unpacker) extendsPending(r rune) bool {
+	if !u.opts.Graphemes || !u.hasPending || u.counting {
+		return false
+	}
+	if len(u.pending)+utf8.RuneLen(r) > maxGraphemeLen {
+		return false
+	}
+
+	// Графема продолжается, если вместе с r первая графема занимает всю строку
+	candidate := u.pending + string(r)
+	cluster, _, _, _ := uniseg.FirstGraphemeClusterInString(candidate, -1)
+	return len(cluster) == len(candidate)
+}
+
+// flushPending добавляет ожидающий символ в результат:
+// count раз, если за ним был счётчик, иначе один раз как есть.
+func (u *unpacker) flushPending() error {
+	if !u.hasPending {
+		return nil
+	}
+
+	count, offset := 1, u.pendingOffset
+	if u.counting {
+		count, offset = u.count, u.countOffset
+	}
+
+	u.hasPending = false
+	u.counting = false
+	return u.writeRepeated(u.pending, count, offset)
+}
+
+// writeRepeated записывает символ (или графему) s count раз (при count == 0 символ пропадает).
+// offset - смещение, о котором сообщается при превышении Options.MaxOutputLen.
+func (u *unpacker) writeRepeated(s string, count, offset int) error {
+	// Проверяем лимит без умножения, чтобы не переполнить int
+	if u.opts.MaxOutputLen > 0 && count > (u.opts.MaxOutputLen-u.written)/len(s) {
+		return tooLargeAt(offset)
+	}
 
-		// Возвращаем i+1, чтобы пропустить следующий символ (цифру)
-		// Она уже обработана как счётчик повторений
-		return i + 1
+	// Большие счётчики записываем частями, не собирая весь повтор в памяти
+	for rest := count; rest > 0; rest -= repeatChunk {
+		n := rest
+		if n > repeatChunk {
+			n = repeatChunk
+		}
+		// strings.Repeat создаёт строку из n повторений символа
+		if _, err := u.out.WriteString(strings.Repeat(s, n)); err != nil {
+			return err
+		}
 	}
 
-	// Следующий символ не цифра или его нет
-	// Добавляем текущий символ один раз как есть
-	result.WriteRune(currentRune)
-	return i
+	u.written += count * len(s)
+	return nil
+}
+
+// finish завершает распаковку после последнего символа строки.
+// Возвращает ошибку, если строка оканчивается на незакрытый слэш.
+func (u *unpacker) finish() error {
+	if u.escaped {
+		return &UnpackError{
+			RuneIndex: u.escapeIndex,
+			Offset:    u.escapeOffset,
+			Rune:      escapeRune,
+			Reason:    ReasonDanglingEscape,
+		}
+	}
+	return u.flushPending()
 }
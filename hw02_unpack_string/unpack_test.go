@@ -16,11 +16,10 @@ func TestUnpack(t *testing.T) {
 		{input: "abccd", expected: "abccd"},
 		{input: "", expected: ""},
 		{input: "aaa0b", expected: "aab"},
-		// uncomment if task with asterisk completed
-		// {input: `qwe\4\5`, expected: `qwe45`},
-		// {input: `qwe\45`, expected: `qwe44444`},
-		// {input: `qwe\\5`, expected: `qwe\\\\\`},
-		// {input: `qwe\\\3`, expected: `qwe\3`},
+		{input: `qwe\4\5`, expected: `qwe45`},
+		{input: `qwe\45`, expected: `qwe44444`},
+		{input: `qwe\\5`, expected: `qwe\\\\\`},
+		{input: `qwe\\\3`, expected: `qwe\3`},
 	}
 
 	for _, tc := range tests {
@@ -34,7 +33,7 @@ func TestUnpack(t *testing.T) {
 }
 
 func TestUnpackInvalidString(t *testing.T) {
-	invalidStrings := []string{"3abc", "45", "aaa10b"}
+	invalidStrings := []string{"3abc", "45", "aaa10b", `qw\ne`}
 	for _, tc := range invalidStrings {
 		tc := tc
 		t.Run(tc, func(t *testing.T) {
@@ -89,3 +88,54 @@ func TestUnpackInvalid(t *testing.T) {
 		})
 	}
 }
+
+// Тесты на экранирование.
+func TestUnpackEscape(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "escaped digit only", input: `\5`, expected: `5`},
+		{name: "escaped slash only", input: `\\`, expected: `\`},
+		{name: "escaped zero with count", input: `\03`, expected: `000`},
+		{name: "escaped digit removed by zero", input: `a\50b`, expected: `ab`},
+		{name: "escaped slash removed by zero", input: `a\\0b`, expected: `ab`},
+		{name: "slash repeated before escaped digit", input: `\\2\2`, expected: `\\2`},
+		{name: "unicode after escape", input: `\1п2`, expected: `1пп`},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Unpack(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+// Тесты на некорректное экранирование.
+func TestUnpackInvalidEscape(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "escaped letter", input: `a\b2`},
+		{name: "escaped unicode", input: `\п`},
+		{name: "escaped newline", input: "a\\\n"},
+		{name: "trailing slash", input: `abc\`},
+		{name: "only slash", input: `\`},
+		{name: "odd slashes at end", input: `a\\\`},
+		{name: "number after escaped digit", input: `\456`},
+		{name: "number after escaped slash", input: `\\12`},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Unpack(tc.input)
+			require.Truef(t, errors.Is(err, ErrInvalidString), "actual error %q", err)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package hw03frequencyanalysis
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidCapacity  = errors.New("capacity must be greater than zero")
+	ErrInvalidErrorRate = errors.New("error rate must be in range (0, 1)")
+)
+
+// ApproxWordCount - слово и приближённое количество его вхождений.
+// Истинное количество лежит в диапазоне [Count-Error, Count].
+type ApproxWordCount struct {
+	Word  string // слово из потока
+	Count int    // оценка сверху количества вхождений
+	Error int    // максимальная переоценка Count
+}
+
+// HeavyHitters приближённо подсчитывает самые частые слова в бесконечном потоке
+// по алгоритму Space-Saving, используя не больше capacity счётчиков.
+//
+// Гарантии для потока из N слов:
+//   - каждое слово, встретившееся больше N/capacity раз, присутствует среди счётчиков;
+//   - Count переоценивает истинную частоту не больше чем на N/capacity.
+//
+// Безопасен для использования из нескольких горутин.
+type HeavyHitters struct {
+	mu       sync.Mutex
+	cfg      config
+	capacity int                     // максимальное количество отслеживаемых слов
+	counters map[string]*heavyHitter // слово -> его счётчик
+	minHeap  heavyHitterHeap         // счётчики, в корне - с наименьшим значением
+	window   *ngramWindow            // сборка n-грамм при WithNGrams
+	total    int                     // сколько слов (n-грамм) добавлено
+}
+
+// heavyHitter - счётчик отслеживаемого слова.
+type heavyHitter struct {
+	wordFreq     // слово, оценка частоты и номер, под которым слово начали отслеживать
+	err      int // максимальная переоценка count
+	index    int // позиция в куче для heap.Fix
+}
+
+// NewHeavyHitters создаёт счётчик с фиксированным числом отслеживаемых слов.
+// Память ограничена capacity счётчиками независимо от длины потока.
+// Опции WithNormalizedWords, WithStopWords, WithNGrams, WithTokenizer и WithTieBreak
+// применяются так же, как в TopN.
+func NewHeavyHitters(capacity int, opts ...Option) (*HeavyHitters, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	cfg := newConfig(opts)
+	return &HeavyHitters{
+		cfg:      cfg,
+		capacity: capacity,
+		counters: make(map[string]*heavyHitter, capacity),
+		minHeap:  make(heavyHitterHeap, 0, capacity),
+		window:   newNGramWindow(cfg.ngram, cfg.stopWords),
+	}, nil
+}
+
+// NewHeavyHittersWithError создаёт счётчик, переоценка частот которого не превышает
+// errorRate*N для потока из N слов. Требует ceil(1/errorRate) счётчиков.
+func NewHeavyHittersWithError(errorRate float64, opts ...Option) (*HeavyHitters, error) {
+	if !(errorRate > 0 && errorRate < 1) {
+		return nil, ErrInvalidErrorRate
+	}
+	return NewHeavyHitters(int(math.Ceil(1/errorRate)), opts...)
+}
+
+// Add учитывает очередное слово потока.
+func (h *HeavyHitters) Add(word string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.addWord(word)
+}
+
+// AddText разбивает текст на слова и учитывает каждое из них.
+func (h *HeavyHitters) AddText(text string) {
+	words := h.cfg.tokenizer.Tokenize(text)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, word := range words {
+		h.addWord(word)
+	}
+}
+
+// Top возвращает до n слов с наибольшей оценкой частоты на текущий момент.
+func (h *HeavyHitters) Top(n int) []ApproxWordCount {
+	if n <= 0 {
+		return []ApproxWordCount{}
+	}
+
+	h.mu.Lock()
+	hitters := make([]heavyHitter, len(h.minHeap))
+	for i, hitter := range h.minHeap {
+		hitters[i] = *hitter
+	}
+	h.mu.Unlock()
+
+	sort.Slice(hitters, func(i, j int) bool {
+		return h.cfg.tieBreak.less(hitters[i].wordFreq, hitters[j].wordFreq)
+	})
+
+	if len(hitters) > n {
+		hitters = hitters[:n]
+	}
+	result := make([]ApproxWordCount, len(hitters))
+	for i, hitter := range hitters {
+		result[i] = ApproxWordCount{Word: hitter.word, Count: hitter.count, Error: hitter.err}
+	}
+	return result
+}
+
+// Total возвращает количество учтённых слов (или n-грамм) потока.
+func (h *HeavyHitters) Total() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.total
+}
+
+// ErrorBound возвращает текущую гарантированную границу переоценки частот: N/capacity.
+func (h *HeavyHitters) ErrorBound() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.total / h.capacity
+}
+
+// addWord применяет к слову настройки и учитывает его. Вызывается под мьютексом.
+func (h *HeavyHitters) addWord(word string) {
+	word, ok := prepareWord(word, h.cfg)
+	if !ok {
+		return
+	}
+	gram, ok := h.window.push(word)
+	if !ok {
+		return
+	}
+
+	h.increment(gram)
+	h.total++
+}
+
+// increment - шаг алгоритма Space-Saving.
+func (h *HeavyHitters) increment(word string) {
+	// Слово уже отслеживается - увеличиваем его счётчик
+	if hitter, ok := h.counters[word]; ok {
+		hitter.count++
+		heap.Fix(&h.minHeap, hitter.index)
+		return
+	}
+
+	// Слово из strings.Fields - подстрока всего текста: копируем его,
+	// чтобы отслеживаемые слова не удерживали в памяти весь поток
+	word = strings.Clone(word)
+
+	// Есть свободный счётчик - начинаем отслеживать слово
+	if len(h.minHeap) < h.capacity {
+		hitter := &heavyHitter{wordFreq: wordFreq{word: word, count: 1, first: h.total}}
+		heap.Push(&h.minHeap, hitter)
+		h.counters[word] = hitter
+		return
+	}
+
+	// Свободных счётчиков нет - вытесняем слово с минимальным счётчиком.
+	// Новое слово могло встречаться до min раз, пока не отслеживалось: это его ошибка
+	hitter := h.minHeap[0]
+	delete(h.counters, hitter.word)
+
+	hitter.err = hitter.count
+	hitter.count++
+	hitter.word = word
+	hitter.first = h.total
+	h.counters[word] = hitter
+	heap.Fix(&h.minHeap, 0)
+}
+
+// heavyHitterHeap - куча счётчиков с наименьшим значением в корне.
+// Реализует heap.Interface.
+type heavyHitterHeap []*heavyHitter
+
+func (h heavyHitterHeap) Len() int {
+	return len(h)
+}
+
+func (h heavyHitterHeap) Less(i, j int) bool {
+	return h[i].count < h[j].count
+}
+
+func (h heavyHitterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *heavyHitterHeap) Push(x interface{}) {
+	hitter := x.(*heavyHitter)
+	hitter.index = len(*h)
+	*h = append(*h, hitter)
+}
+
+func (h *heavyHitterHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,137 @@
+package hw03frequencyanalysis
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeavyHitters(t *testing.T) {
+	t.Run("exact when capacity fits all words", func(t *testing.T) {
+		hh, err := NewHeavyHitters(1000)
+		require.NoError(t, err)
+		hh.AddText(text)
+
+		expected := TopN(text, 10)
+		result := hh.Top(10)
+		require.Len(t, result, len(expected))
+		for i := range expected {
+			require.Equal(t, ApproxWordCount{Word: expected[i].Word, Count: expected[i].Count}, result[i])
+		}
+		require.Equal(t, len(strings.Fields(text)), hh.Total())
+	})
+
+	t.Run("error bounds", func(t *testing.T) {
+		const capacity = 50
+		words := strings.Fields(benchmarkText(100_000, 5_000))
+
+		hh, err := NewHeavyHitters(capacity)
+		require.NoError(t, err)
+		for _, word := range words {
+			hh.Add(word)
+		}
+
+		exact := make(map[string]int)
+		for _, word := range words {
+			exact[word]++
+		}
+
+		bound := len(words) / capacity
+		require.Equal(t, bound, hh.ErrorBound())
+
+		approx := make(map[string]ApproxWordCount)
+		for _, wc := range hh.Top(capacity) {
+			approx[wc.Word] = wc
+			// Истинная частота лежит в [Count-Error, Count], а Error не больше N/capacity
+			require.LessOrEqual(t, wc.Count-wc.Error, exact[wc.Word])
+			require.GreaterOrEqual(t, wc.Count, exact[wc.Word])
+			require.LessOrEqual(t, wc.Error, bound)
+		}
+
+		// Все слова с частотой больше N/capacity обязательно найдены
+		for word, count := range exact {
+			if count > bound {
+				require.Contains(t, approx, word)
+			}
+		}
+	})
+
+	t.Run("top of empty counter", func(t *testing.T) {
+		hh, err := NewHeavyHitters(10)
+		require.NoError(t, err)
+		require.Len(t, hh.Top(10), 0)
+		require.Equal(t, 0, hh.ErrorBound())
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		hh, err := NewHeavyHitters(2)
+		require.NoError(t, err)
+		hh.AddText("a a a b c")
+
+		// "c" вытеснил "b" и унаследовал его счётчик как ошибку
+		expected := []ApproxWordCount{
+			{Word: "a", Count: 3},
+			{Word: "c", Count: 2, Error: 1},
+		}
+		require.Equal(t, expected, hh.Top(10))
+	})
+
+	t.Run("options", func(t *testing.T) {
+		hh, err := NewHeavyHitters(10, WithNormalizedWords(), WithStopWords(EnglishStopWords()), WithNGrams(2))
+		require.NoError(t, err)
+		// n-граммы собираются через границы вызовов
+		hh.AddText("The big")
+		hh.Add("Dog,")
+		hh.AddText("and the big dog")
+
+		// n-граммы со стоп-словами "the" и "and" не учитываются
+		require.Equal(t, []ApproxWordCount{{Word: "big dog", Count: 2}}, hh.Top(10))
+		require.Equal(t, 2, hh.Total())
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, err := NewHeavyHitters(0)
+		require.True(t, errors.Is(err, ErrInvalidCapacity))
+
+		for _, rate := range []float64{0, 1, -0.5, 2} {
+			_, err = NewHeavyHittersWithError(rate)
+			require.True(t, errors.Is(err, ErrInvalidErrorRate), "rate %v", rate)
+		}
+	})
+
+	t.Run("capacity from error rate", func(t *testing.T) {
+		hh, err := NewHeavyHittersWithError(0.01)
+		require.NoError(t, err)
+		require.Equal(t, 100, hh.capacity)
+	})
+}
+
+func TestHeavyHittersConcurrent(t *testing.T) {
+	hh, err := NewHeavyHitters(20)
+	require.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				hh.Add("hot")
+				hh.AddText("a b c hot")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				hh.Top(5)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, ApproxWordCount{Word: "hot", Count: 16000}, hh.Top(1)[0])
+	require.Equal(t, 40000, hh.Total())
+}
@@ -0,0 +1,66 @@
+package hw03frequencyanalysis
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Регулярные выражения компилируются один раз на уровне пакета, а не при каждом вызове.
+var (
+	// edgePunctuation находит знаки препинания в начале и в конце слова.
+	edgePunctuation = regexp.MustCompile(`^\p{P}+|\p{P}+$`)
+	// dashWord находит слова из нескольких тире - они считаются словами целиком.
+	dashWord = regexp.MustCompile(`^-{2,}$`)
+)
+
+// normalizeWord приводит слово к нижнему регистру и обрезает знаки препинания по краям.
+// Возвращает false, если после обработки от слова ничего не осталось (например, "-").
+func normalizeWord(word string) (string, bool) {
+	// "-------" - слово, его не обрезаем
+	if dashWord.MatchString(word) {
+		return word, true
+	}
+
+	word = edgePunctuation.ReplaceAllString(word, "")
+	if word == "" {
+		return "", false
+	}
+
+	return strings.ToLower(word), true
+}
+
+// prepareWord применяет к слову нормализацию и фильтр стоп-слов, если они включены.
+// Возвращает false, если слово нужно пропустить.
+// При подсчёте n-грамм стоп-слова не пропускаются: иначе n-граммы составлялись бы
+// из слов, между которыми в тексте стоит стоп-слово. Такие n-граммы отбрасываются при подсчёте.
+func prepareWord(word string, cfg config) (string, bool) {
+	if cfg.normalize {
+		var ok bool
+		if word, ok = normalizeWord(word); !ok {
+			return "", false
+		}
+	}
+
+	// Стоп-слова сравниваются с уже нормализованным словом
+	if cfg.ngram <= 1 && cfg.stopWords.Contains(word) {
+		return "", false
+	}
+
+	return word, true
+}
+
+// prepareWords применяет prepareWord ко всем словам и отбрасывает пропущенные.
+func prepareWords(words []string, cfg config) []string {
+	if !cfg.normalize && len(cfg.stopWords) == 0 {
+		return words
+	}
+
+	// Фильтруем на месте, переиспользуя исходный слайс
+	result := words[:0]
+	for _, word := range words {
+		if prepared, ok := prepareWord(word, cfg); ok {
+			result = append(result, prepared)
+		}
+	}
+	return result
+}
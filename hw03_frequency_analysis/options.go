@@ -0,0 +1,102 @@
+package hw03frequencyanalysis
+
+import "runtime"
+
+// Option настраивает частотный анализ.
+type Option func(*config)
+
+// config - итоговые настройки анализа, собранные из опций.
+type config struct {
+	tokenizer Tokenizer // разбиение текста на слова
+	tieBreak  TieBreak  // порядок слов с одинаковой частотой
+	normalize bool      // приводить слова к нижнему регистру и обрезать знаки препинания по краям
+	workers   int       // количество горутин для подсчёта частот (<= 1 - последовательно)
+	stopWords StopWords // слова, исключаемые из анализа
+	ngram     int       // количество слов в n-грамме (1 - отдельные слова)
+}
+
+// newConfig применяет опции к настройкам по умолчанию.
+func newConfig(opts []Option) config {
+	cfg := config{
+		tokenizer: FieldsTokenizer,
+		tieBreak:  TieBreakLexicographic,
+		ngram:     1,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithNormalizedWords включает режим без учёта регистра и знаков препинания по краям слова:
+//   - "Нога", "нога!", "нога," и "'нога'" считаются одним словом "нога";
+//   - дефисы внутри слова сохраняются: "какой-то" и "какойто" - разные слова;
+//   - "-" словом не является, а "-------" является.
+func WithNormalizedWords() Option {
+	return func(c *config) {
+		c.normalize = true
+	}
+}
+
+// WithTokenizer задаёт способ разбиения текста на слова вместо FieldsTokenizer.
+// При nil используется FieldsTokenizer.
+func WithTokenizer(t Tokenizer) Option {
+	return func(c *config) {
+		if t != nil {
+			c.tokenizer = t
+		}
+	}
+}
+
+// WithTieBreak задаёт порядок слов с одинаковой частотой.
+func WithTieBreak(t TieBreak) Option {
+	return func(c *config) {
+		c.tieBreak = t
+	}
+}
+
+// WithWorkers включает параллельный подсчёт частот в workers горутинах:
+// текст делится на части по пробельным символам, каждая часть считается в своём map,
+// затем map объединяются. Результат совпадает с последовательным подсчётом.
+// При workers <= 0 используется runtime.NumCPU().
+//
+// Токенизатор из WithTokenizer применяется к каждой части отдельно,
+// поэтому он не должен объединять в одно слово символы, разделённые пробелами.
+func WithWorkers(workers int) Option {
+	return func(c *config) {
+		if workers <= 0 {
+			workers = runtime.NumCPU()
+		}
+		c.workers = workers
+	}
+}
+
+// WithStopWords исключает из анализа слова из переданных наборов, например RussianStopWords().
+// Повторные вызовы объединяют наборы. Стоп-слова сравниваются со словом после нормализации,
+// поэтому для наборов в нижнем регистре обычно нужен и WithNormalizedWords.
+func WithStopWords(sets ...StopWords) Option {
+	return func(c *config) {
+		for _, set := range sets {
+			for word := range set {
+				if c.stopWords == nil {
+					c.stopWords = make(StopWords)
+				}
+				c.stopWords[word] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithNGrams включает подсчёт n-грамм - n подряд идущих слов, записанных через пробел,
+// например биграмм (n = 2) и триграмм (n = 3). Токенизация, нормализация, стоп-слова
+// и порядок при равной частоте те же, что и для отдельных слов; n-граммы, в которые
+// входит стоп-слово, не учитываются, поэтому "кот и пёс" не даёт биграмму "кот пёс".
+// При n <= 1 считаются отдельные слова.
+func WithNGrams(n int) Option {
+	return func(c *config) {
+		if n < 1 {
+			n = 1
+		}
+		c.ngram = n
+	}
+}
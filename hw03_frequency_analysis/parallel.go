@@ -0,0 +1,115 @@
+package hw03frequencyanalysis
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// buildFrequencyMapParallel подсчитывает частоты слов в cfg.workers горутинах.
+// Каждая горутина считает свою часть текста в отдельный map, поэтому синхронизация
+// нужна только при объединении результатов.
+func buildFrequencyMapParallel(text string, cfg config) map[string]*wordFreq {
+	shards := splitShards(text, cfg.workers)
+
+	// Этап 1: разбиваем части на слова
+	words := make([][]string, len(shards))
+	runParallel(len(shards), func(i int) {
+		words[i] = splitWords(shards[i], cfg)
+	})
+
+	// Этап 2: считаем частоты. Каждая часть получает сквозной номер своего первого слова,
+	// чтобы место первого вхождения совпадало с последовательным подсчётом,
+	// и слова следующих частей, чтобы дописать n-граммы на границе
+	counters := make([]*frequencyCounter, len(shards))
+	offset := 0
+	for i := range shards {
+		counters[i] = newFrequencyCounter()
+		counters[i].total = offset
+		offset += len(words[i])
+	}
+	runParallel(len(shards), func(i int) {
+		counters[i].addNGrams(words[i], lookahead(words[i+1:], cfg.ngram-1), cfg)
+	})
+
+	return mergeCounters(counters)
+}
+
+// runParallel вызывает fn(i) для каждого i от 0 до n-1 в отдельной горутине и ждёт завершения всех.
+func runParallel(n int, fn func(i int)) {
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// lookahead возвращает до count первых слов из следующих частей текста.
+func lookahead(next [][]string, count int) []string {
+	var result []string
+	for _, words := range next {
+		if len(result) >= count {
+			break
+		}
+		rest := count - len(result)
+		if rest > len(words) {
+			rest = len(words)
+		}
+		result = append(result, words[:rest]...)
+	}
+	return result
+}
+
+// splitShards делит текст примерно на parts равных частей.
+// Границы частей сдвигаются до ближайшего пробельного символа,
+// поэтому ни одно слово не разрезается между частями.
+func splitShards(text string, parts int) []string {
+	shards := make([]string, 0, parts)
+
+	start := 0
+	for i := 1; i < parts && start < len(text); i++ {
+		end := i * len(text) / parts
+		if end <= start {
+			continue
+		}
+
+		// Сдвигаем границу до следующего пробельного символа
+		next := strings.IndexFunc(text[end:], unicode.IsSpace)
+		if next < 0 {
+			break
+		}
+		end += next
+
+		shards = append(shards, text[start:end])
+		start = end
+	}
+
+	// Остаток текста - последняя часть
+	return append(shards, text[start:])
+}
+
+// mergeCounters объединяет частоты, подсчитанные по частям текста.
+func mergeCounters(counters []*frequencyCounter) map[string]*wordFreq {
+	frequency := make(map[string]*wordFreq)
+
+	for _, counter := range counters {
+		for word, wf := range counter.frequency {
+			merged, ok := frequency[word]
+			if !ok {
+				frequency[word] = wf
+				continue
+			}
+			merged.count += wf.count
+			// Первое вхождение - самое раннее из всех частей
+			if wf.first < merged.first {
+				merged.first = wf.first
+			}
+		}
+	}
+
+	return frequency
+}
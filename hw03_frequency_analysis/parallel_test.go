@@ -0,0 +1,80 @@
+package hw03frequencyanalysis
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopNParallel(t *testing.T) {
+	optionSets := map[string][]Option{
+		"default":          nil,
+		"normalized":       {WithNormalizedWords()},
+		"first occurrence": {WithTieBreak(TieBreakFirstOccurrence)},
+		"reverse":          {WithTieBreak(TieBreakReverseLexicographic), WithNormalizedWords()},
+	}
+
+	for name, opts := range optionSets {
+		opts := opts
+		t.Run(name, func(t *testing.T) {
+			for _, workers := range []int{0, 2, 3, 7, 16, 1000} {
+				parallelOpts := append([]Option{WithWorkers(workers)}, opts...)
+				require.Equal(t, TopN(text, 1000, opts...), TopN(text, 1000, parallelOpts...), "workers %d", workers)
+				require.Equal(t, Top10(text, opts...), Top10(text, parallelOpts...), "workers %d", workers)
+			}
+		})
+	}
+}
+
+func TestTopNParallelRandomText(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	alphabet := []string{"a", "b", "в", "г", "-", ",", "ё"}
+	separators := []string{" ", "  ", "\n", "\t", " "}
+
+	for i := 0; i < 50; i++ {
+		var sb strings.Builder
+		for j := rnd.Intn(300); j > 0; j-- {
+			for k := rnd.Intn(3) + 1; k > 0; k-- {
+				sb.WriteString(alphabet[rnd.Intn(len(alphabet))])
+			}
+			sb.WriteString(separators[rnd.Intn(len(separators))])
+		}
+		text := sb.String()
+
+		expected := TopN(text, 50, WithTieBreak(TieBreakFirstOccurrence))
+		actual := TopN(text, 50, WithTieBreak(TieBreakFirstOccurrence), WithWorkers(rnd.Intn(8)+2))
+		require.Equal(t, expected, actual, "text %q", text)
+	}
+}
+
+func TestSplitShards(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		parts int
+	}{
+		{name: "empty", text: "", parts: 4},
+		{name: "single word", text: "словословослово", parts: 4},
+		{name: "more parts than words", text: "a b", parts: 10},
+		{name: "unicode", text: "привет мир привет мир привет", parts: 3},
+		{name: "leading and trailing spaces", text: "  a b c d  ", parts: 2},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			shards := splitShards(tc.text, tc.parts)
+			require.LessOrEqual(t, len(shards), tc.parts)
+			// Части вместе дают исходный текст и не разрезают слова
+			require.Equal(t, tc.text, strings.Join(shards, ""))
+
+			words := []string{}
+			for _, shard := range shards {
+				words = append(words, strings.Fields(shard)...)
+			}
+			require.Equal(t, strings.Fields(tc.text), words)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package hw03frequencyanalysis
+
+// StopWords - набор слов, исключаемых из частотного анализа.
+type StopWords map[string]struct{}
+
+// NewStopWords создаёт набор стоп-слов из перечисленных слов.
+func NewStopWords(words ...string) StopWords {
+	set := make(StopWords, len(words))
+	for _, word := range words {
+		set[word] = struct{}{}
+	}
+	return set
+}
+
+// Contains проверяет, входит ли слово в набор.
+func (s StopWords) Contains(word string) bool {
+	_, ok := s[word]
+	return ok
+}
+
+// containsAny проверяет, входит ли в набор хотя бы одно из слов.
+func (s StopWords) containsAny(words []string) bool {
+	for _, word := range words {
+		if s.Contains(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// RussianStopWords возвращает встроенный набор русских стоп-слов в нижнем регистре:
+// предлоги, союзы, частицы, местоимения.
+func RussianStopWords() StopWords {
+	return NewStopWords(russianStopWords...)
+}
+
+// EnglishStopWords возвращает встроенный набор английских стоп-слов в нижнем регистре.
+func EnglishStopWords() StopWords {
+	return NewStopWords(englishStopWords...)
+}
+
+var russianStopWords = []string{
+	"а", "без", "бы", "был", "была", "были", "было", "быть", "в", "вам", "вас", "во", "вот", "все",
+	"всё", "вы", "где", "да", "даже", "для", "до", "его", "ее", "её", "если", "есть", "еще", "ещё",
+	"же", "за", "и", "из", "или", "им", "их", "к", "как", "когда", "ко", "кто", "ли", "мы", "на",
+	"над", "не", "нет", "ни", "но", "ну", "о", "об", "он", "она", "они", "оно", "от", "по", "под",
+	"при", "с", "со", "так", "также", "то", "тоже", "только", "у", "уже", "чем", "что", "чтобы",
+	"это", "этот", "я",
+}
+
+var englishStopWords = []string{
+	"a", "about", "after", "all", "also", "am", "an", "and", "any", "are", "as", "at", "be",
+	"because", "been", "but", "by", "can", "could", "did", "do", "does", "for", "from", "had",
+	"has", "have", "he", "her", "him", "his", "how", "i", "if", "in", "into", "is", "it", "its",
+	"me", "my", "no", "not", "of", "on", "or", "our", "she", "so", "than", "that", "the", "their",
+	"them", "then", "there", "these", "they", "this", "to", "up", "was", "we", "were", "what",
+	"when", "which", "who", "will", "with", "would", "you", "your",
+}
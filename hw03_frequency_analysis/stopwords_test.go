@@ -0,0 +1,122 @@
+package hw03frequencyanalysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopWords(t *testing.T) {
+	t.Run("russian", func(t *testing.T) {
+		result := Top10(text, WithNormalizedWords(), WithStopWords(RussianStopWords()))
+		for _, word := range result {
+			require.False(t, RussianStopWords().Contains(word), "stop word %q in result", word)
+		}
+		require.Contains(t, result, "кристофер")
+	})
+
+	t.Run("english", func(t *testing.T) {
+		result := Top10("The cat and the dog and THE bird", WithNormalizedWords(), WithStopWords(EnglishStopWords()))
+		require.Equal(t, []string{"bird", "cat", "dog"}, result)
+	})
+
+	t.Run("custom and built-in sets are combined", func(t *testing.T) {
+		opts := []Option{
+			WithNormalizedWords(),
+			WithStopWords(EnglishStopWords()),
+			WithStopWords(NewStopWords("cat")),
+		}
+		require.Equal(t, []string{"bird", "dog"}, Top10("the cat and the dog and the bird", opts...))
+	})
+
+	t.Run("compared after normalization", func(t *testing.T) {
+		// Без нормализации "The" не совпадает со стоп-словом "the"
+		result := Top10("The the cat", WithStopWords(EnglishStopWords()))
+		require.Equal(t, []string{"The", "cat"}, result)
+	})
+
+	t.Run("built-in sets are independent copies", func(t *testing.T) {
+		set := RussianStopWords()
+		delete(set, "и")
+		require.True(t, RussianStopWords().Contains("и"))
+	})
+}
+
+func TestNGrams(t *testing.T) {
+	t.Run("bigrams", func(t *testing.T) {
+		result := TopN("a b a b c a b", 3, WithNGrams(2))
+		expected := []WordCount{
+			{Word: "a b", Count: 3},
+			{Word: "b a", Count: 1},
+			{Word: "b c", Count: 1},
+		}
+		require.Equal(t, expected, result)
+	})
+
+	t.Run("trigrams with normalization", func(t *testing.T) {
+		result := TopN("Винни-Пух любит мёд. винни-пух любит мёд! Винни-Пух любит", 1,
+			WithNGrams(3), WithNormalizedWords())
+		require.Equal(t, []WordCount{{Word: "винни-пух любит мёд", Count: 2}}, result)
+	})
+
+	t.Run("n-grams with stop words are skipped", func(t *testing.T) {
+		result := Top10("cat and dog cat or dog", WithNGrams(2), WithStopWords(EnglishStopWords()))
+		require.Equal(t, []string{"dog cat"}, result)
+	})
+
+	t.Run("n-grams are not formed across stop words", func(t *testing.T) {
+		const text = "кот и пёс, кот и пёс. Кот спит"
+		opts := []Option{WithNGrams(2), WithNormalizedWords(), WithStopWords(RussianStopWords())}
+		// Без стоп-слова "и" между ними "кот" и "пёс" не образуют биграмму
+		expected := []WordCount{{Word: "пёс кот", Count: 2}, {Word: "кот спит", Count: 1}}
+
+		require.Equal(t, expected, TopN(text, 10, opts...))
+		require.Equal(t, expected, TopN(text, 10, append(opts, WithWorkers(3))...))
+
+		fromReader, err := TopNFromReader(strings.NewReader(text), 10, opts...)
+		require.NoError(t, err)
+		require.Equal(t, expected, fromReader)
+
+		hh, err := NewHeavyHitters(10, opts...)
+		require.NoError(t, err)
+		hh.AddText(text)
+		require.Equal(t, []ApproxWordCount{{Word: "пёс кот", Count: 2}, {Word: "кот спит", Count: 1}}, hh.Top(10))
+	})
+
+	t.Run("fewer words than n", func(t *testing.T) {
+		require.Len(t, Top10("a b", WithNGrams(3)), 0)
+	})
+
+	t.Run("n <= 1 counts words", func(t *testing.T) {
+		require.Equal(t, Top10(text), Top10(text, WithNGrams(0)))
+	})
+
+	t.Run("tie break", func(t *testing.T) {
+		result := Top10("c d a b", WithNGrams(2), WithTieBreak(TieBreakFirstOccurrence))
+		require.Equal(t, []string{"c d", "d a", "a b"}, result)
+	})
+}
+
+func TestNGramsAllPathsEqual(t *testing.T) {
+	for _, n := range []int{2, 3, 5} {
+		opts := []Option{
+			WithNGrams(n),
+			WithNormalizedWords(),
+			WithStopWords(RussianStopWords()),
+			WithTieBreak(TieBreakFirstOccurrence),
+		}
+		expected := TopN(text, 1000, opts...)
+		require.NotEmpty(t, expected)
+
+		fromReader, err := TopNFromReader(strings.NewReader(text), 1000, opts...)
+		require.NoError(t, err)
+		require.Equal(t, expected, fromReader, "reader, n = %d", n)
+
+		// Много частей по несколько слов: n-граммы пересекают границы нескольких частей
+		for _, workers := range []int{2, 7, 100, 1000} {
+			parallel := TopN(text, 1000, append(opts, WithWorkers(workers))...)
+			require.Equal(t, expected, parallel, "workers %d, n = %d", workers, n)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package hw03frequencyanalysis
+
+import (
+	"bufio"
+	"container/heap"
+	"io"
+)
+
+// TopNFromReader - потоковый вариант TopN: читает текст из r по словам,
+// не загружая его в память целиком, и выбирает n самых частых слов
+// через ограниченную кучу за O(U log N), где U - количество уникальных слов.
+// Результат совпадает с TopN для того же текста.
+//
+// Текст разбивается на слова по пробельным символам; токенизатор из WithTokenizer
+// применяется к каждому такому слову отдельно. Возвращает ошибку чтения r
+// или bufio.ErrTooLong, если слово длиннее буфера сканера.
+func TopNFromReader(r io.Reader, n int, opts ...Option) ([]WordCount, error) {
+	if n <= 0 {
+		return []WordCount{}, nil
+	}
+
+	cfg := newConfig(opts)
+	counter := newFrequencyCounter()
+
+	window := newNGramWindow(cfg.ngram, cfg.stopWords)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		for _, word := range splitWords(scanner.Text(), cfg) {
+			if gram, ok := window.push(word); ok {
+				counter.add(gram)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return extractTopWords(selectTopN(counter.frequency, n, cfg.tieBreak), n), nil
+}
+
+// selectTopN выбирает n лучших слов без полной сортировки:
+// в куче хранятся n лучших из просмотренных слов, в корне - худшее из них.
+// Возвращает выбранные слова, упорядоченные от лучшего к худшему.
+func selectTopN(frequency map[string]*wordFreq, n int, tieBreak TieBreak) []wordFreq {
+	h := &wordHeap{items: make([]wordFreq, 0, n), tieBreak: tieBreak}
+
+	for _, wf := range frequency {
+		if h.Len() < n {
+			heap.Push(h, *wf)
+			continue
+		}
+		// Слово лучше худшего из выбранных - заменяем корень
+		if tieBreak.less(*wf, h.items[0]) {
+			h.items[0] = *wf
+			heap.Fix(h, 0)
+		}
+	}
+
+	// Извлекаем слова от худшего к лучшему и заполняем результат с конца
+	result := make([]wordFreq, h.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(h).(wordFreq)
+	}
+	return result
+}
+
+// wordHeap - куча слов, в корне которой худшее слово по правилам tieBreak.
+// Реализует heap.Interface.
+type wordHeap struct {
+	items    []wordFreq
+	tieBreak TieBreak
+}
+
+func (h *wordHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *wordHeap) Less(i, j int) bool {
+	// Обратный порядок: худшее слово поднимается в корень
+	return h.tieBreak.less(h.items[j], h.items[i])
+}
+
+func (h *wordHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *wordHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(wordFreq))
+}
+
+func (h *wordHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
@@ -0,0 +1,67 @@
+package hw03frequencyanalysis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopNFromReader(t *testing.T) {
+	t.Run("same result as TopN", func(t *testing.T) {
+		for _, n := range []int{1, 3, 10, 1000} {
+			result, err := TopNFromReader(strings.NewReader(text), n)
+			require.NoError(t, err)
+			require.Equal(t, TopN(text, n), result)
+		}
+	})
+
+	t.Run("words split between reads", func(t *testing.T) {
+		result, err := TopNFromReader(iotest.OneByteReader(strings.NewReader(text)), 10)
+		require.NoError(t, err)
+		require.Equal(t, TopN(text, 10), result)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		opts := []Option{WithNormalizedWords(), WithTieBreak(TieBreakFirstOccurrence)}
+		result, err := TopNFromReader(strings.NewReader(text), 10, opts...)
+		require.NoError(t, err)
+		require.Equal(t, TopN(text, 10, opts...), result)
+	})
+
+	t.Run("empty input or non-positive n", func(t *testing.T) {
+		result, err := TopNFromReader(strings.NewReader(""), 10)
+		require.NoError(t, err)
+		require.Len(t, result, 0)
+
+		result, err = TopNFromReader(strings.NewReader(text), 0)
+		require.NoError(t, err)
+		require.Len(t, result, 0)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("read failed")
+		_, err := TopNFromReader(iotest.ErrReader(readErr), 10)
+		require.ErrorIs(t, err, readErr)
+	})
+}
+
+func TestSelectTopN(t *testing.T) {
+	words := strings.Fields("d c b a a b c a b a e e e e e f")
+	frequency := buildFrequencyMap(words, newConfig(nil))
+
+	for _, tieBreak := range []TieBreak{TieBreakLexicographic, TieBreakReverseLexicographic, TieBreakFirstOccurrence} {
+		for n := 1; n <= 7; n++ {
+			wordFreqs := mapToSlice(frequency)
+			sortByFrequency(wordFreqs, tieBreak)
+
+			expected := wordFreqs
+			if n < len(expected) {
+				expected = expected[:n]
+			}
+			require.Equal(t, expected, selectTopN(frequency, n, tieBreak))
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package hw03frequencyanalysis
+
+import "strings"
+
+// Tokenizer разбивает текст на слова.
+type Tokenizer interface {
+	Tokenize(text string) []string
+}
+
+// TokenizerFunc позволяет использовать обычную функцию как Tokenizer.
+type TokenizerFunc func(text string) []string
+
+// Tokenize вызывает f(text).
+func (f TokenizerFunc) Tokenize(text string) []string {
+	return f(text)
+}
+
+// FieldsTokenizer - токенизатор по умолчанию: словом считается набор символов,
+// разделённых пробельными символами (strings.Fields).
+var FieldsTokenizer Tokenizer = TokenizerFunc(strings.Fields)
+
+// TieBreak задаёт порядок слов с одинаковой частотой.
+type TieBreak int
+
+const (
+	// TieBreakLexicographic - лексикографически по возрастанию (по умолчанию).
+	TieBreakLexicographic TieBreak = iota
+	// TieBreakReverseLexicographic - лексикографически по убыванию.
+	TieBreakReverseLexicographic
+	// TieBreakFirstOccurrence - в порядке первого появления слова в тексте.
+	TieBreakFirstOccurrence
+)
+
+// less сравнивает слова: сначала по частоте (убывание), при равной частоте - согласно t.
+func (t TieBreak) less(a, b wordFreq) bool {
+	// Если частота разная, сортируем по частоте в порядке убывания (от большего к меньшему)
+	if a.count != b.count {
+		return a.count > b.count
+	}
+
+	switch t {
+	case TieBreakReverseLexicographic:
+		return a.word > b.word
+	case TieBreakFirstOccurrence:
+		return a.first < b.first
+	case TieBreakLexicographic:
+		return a.word < b.word // алфавитный порядок (A-Z)
+	default:
+		return a.word < b.word
+	}
+}
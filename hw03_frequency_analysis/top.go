@@ -7,76 +7,184 @@ import (
 
 const topN = 10
 
-// хранит слово и его частоту использования.
+// WordCount хранит слово и количество его вхождений в текст.
+type WordCount struct {
+	Word  string // слово из текста
+	Count int    // количество вхождений слова
+}
+
+// хранит слово, его частоту использования и место первого вхождения.
 type wordFreq struct {
 	word  string // слово из текста
 	count int    // количество вхождений слова
+	first int    // порядковый номер первого вхождения слова (для TieBreakFirstOccurrence)
 }
 
 // Top10 Возвращает:
 //   - слайс из максимум 10 самых частых слов (или меньше, если уникальных слов меньше 10)
 //   - пустой слайс, если входной текст пустой
-func Top10(sourceText string) []string {
+//
+// Поведение настраивается опциями, например WithNormalizedWords.
+func Top10(sourceText string, opts ...Option) []string {
+	wordCounts := TopN(sourceText, topN, opts...)
+
+	// Для Top10 частоты не нужны, оставляем только слова
+	result := make([]string, len(wordCounts))
+	for i, wc := range wordCounts {
+		result[i] = wc.Word
+	}
+	return result
+}
+
+// TopN возвращает до n самых частых слов вместе с количеством их вхождений.
+// Слова с одинаковой частотой упорядочиваются согласно WithTieBreak (по умолчанию лексикографически).
+// Возвращает пустой слайс, если текст пустой или n <= 0.
+func TopN(sourceText string, n int, opts ...Option) []WordCount {
 	// Обработка пустого текста
-	if sourceText == "" {
-		return []string{}
+	if sourceText == "" || n <= 0 {
+		return []WordCount{}
 	}
 
-	// Разбиваем текст на слова по пробельным символам (пробел, табуляция, перенос строки)
-	// strings.Fields автоматически удаляет пустые элементы
-	words := strings.Fields(sourceText)
+	cfg := newConfig(opts)
 
-	// Подсчитываем частоту каждого слова в тексте
-	frequency := buildFrequencyMap(words)
+	// Подсчитываем частоту каждого слова (или n-граммы) в тексте
+	var frequency map[string]*wordFreq
+	if cfg.workers > 1 {
+		frequency = buildFrequencyMapParallel(sourceText, cfg)
+	} else {
+		frequency = buildFrequencyMap(splitWords(sourceText, cfg), cfg)
+	}
 
 	// Преобразуем map в слайс для возможности сортировки
 	// (map в Go не поддерживает сортировку напрямую)
 	wordFreqs := mapToSlice(frequency)
 
-	sortByFrequencyAndLexicographically(wordFreqs)
+	sortByFrequency(wordFreqs, cfg.tieBreak)
 
 	// Извлекаем первые N слов из отсортированного списка
-	return extractTopWords(wordFreqs, topN)
+	return extractTopWords(wordFreqs, n)
+}
+
+// Разбивает текст на слова согласно настройкам.
+func splitWords(text string, cfg config) []string {
+	// Разбиваем текст на слова (по умолчанию - по пробельным символам),
+	// затем нормализуем их и убираем стоп-слова, если это включено
+	return prepareWords(cfg.tokenizer.Tokenize(text), cfg)
 }
 
-// Подсчитывает частоту встречаемости каждого слова.
+// Подсчитывает частоту встречаемости каждого слова (при cfg.ngram > 1 - каждой n-граммы).
 // Возвращает:
-//   - map, где ключ - слово, значение - количество его вхождений
-func buildFrequencyMap(words []string) map[string]int {
-	frequency := make(map[string]int)
-	// Проходим по всем словам и увеличиваем счётчик для каждого
-	for _, word := range words {
-		frequency[word]++
+//   - map, где ключ - слово, значение - его частота и место первого вхождения
+func buildFrequencyMap(words []string, cfg config) map[string]*wordFreq {
+	counter := newFrequencyCounter()
+	counter.addNGrams(words, nil, cfg)
+	return counter.frequency
+}
+
+// frequencyCounter подсчитывает частоты слов, поступающих по одному.
+type frequencyCounter struct {
+	frequency map[string]*wordFreq // слово -> его частота и место первого вхождения
+	total     int                  // сколько слов уже обработано
+}
+
+func newFrequencyCounter() *frequencyCounter {
+	return &frequencyCounter{frequency: make(map[string]*wordFreq)}
+}
+
+// add учитывает очередное вхождение слова.
+func (c *frequencyCounter) add(word string) {
+	if wf, ok := c.frequency[word]; ok {
+		wf.count++
+	} else {
+		c.frequency[word] = &wordFreq{word: word, count: 1, first: c.total}
 	}
-	return frequency
+	c.total++
+}
+
+// addNGrams учитывает n-граммы, начинающиеся в words. lookahead - слова, следующие за words
+// в тексте: по ним дописываются n-граммы, начатые в конце words.
+// N-грамма - cfg.ngram подряд идущих слов через пробел; при cfg.ngram <= 1 учитываются сами слова.
+// N-граммы со стоп-словами пропускаются.
+func (c *frequencyCounter) addNGrams(words, lookahead []string, cfg config) {
+	n := cfg.ngram
+	if n <= 1 {
+		// Проходим по всем словам и увеличиваем счётчик для каждого
+		for _, word := range words {
+			c.add(word)
+		}
+		return
+	}
+
+	all := words
+	if len(lookahead) > 0 {
+		// Копируем, чтобы не испортить слайс words
+		all = append(words[:len(words):len(words)], lookahead...)
+	}
+
+	for i := 0; i < len(words) && i+n <= len(all); i++ {
+		if cfg.stopWords.containsAny(all[i : i+n]) {
+			// Позицию всё равно учитываем, чтобы место первого вхождения
+			// не зависело от деления текста на части
+			c.total++
+			continue
+		}
+		c.add(strings.Join(all[i:i+n], " "))
+	}
+}
+
+// ngramWindow собирает n-граммы из слов, поступающих по одному.
+type ngramWindow struct {
+	n         int       // количество слов в n-грамме
+	words     []string  // последние слова, из которых собирается очередная n-грамма
+	stopWords StopWords // n-граммы с этими словами пропускаются
+}
+
+func newNGramWindow(n int, stopWords StopWords) *ngramWindow {
+	return &ngramWindow{n: n, words: make([]string, 0, n), stopWords: stopWords}
+}
+
+// push добавляет слово и возвращает n-грамму, которая на нём заканчивается,
+// или false, если слов для n-граммы пока недостаточно или в неё входит стоп-слово.
+func (w *ngramWindow) push(word string) (string, bool) {
+	if w.n <= 1 {
+		return word, true
+	}
+
+	w.words = append(w.words, word)
+	if len(w.words) < w.n {
+		return "", false
+	}
+
+	gram, ok := "", !w.stopWords.containsAny(w.words)
+	if ok {
+		gram = strings.Join(w.words, " ")
+	}
+	// Сдвигаем окно на одно слово
+	w.words = append(w.words[:0], w.words[1:]...)
+	return gram, ok
 }
 
 // Преобразует map частот в слайс структур wordFreq.
-func mapToSlice(frequency map[string]int) []wordFreq {
+func mapToSlice(frequency map[string]*wordFreq) []wordFreq {
 	// Создаём слайс с предварительно выделенной ёмкостью для оптимизации
 	wordFreqs := make([]wordFreq, 0, len(frequency))
 	// Переносим данные из map в слайс
-	for word, count := range frequency {
-		wordFreqs = append(wordFreqs, wordFreq{word: word, count: count})
+	for _, wf := range frequency {
+		wordFreqs = append(wordFreqs, *wf)
 	}
 	return wordFreqs
 }
 
 // Сортирует слова по частоте (убывание),
-// при равной частоте - лексикографически (возрастание).
-func sortByFrequencyAndLexicographically(wordFreqs []wordFreq) {
+// при равной частоте - в порядке tieBreak.
+func sortByFrequency(wordFreqs []wordFreq, tieBreak TieBreak) {
 	sort.Slice(wordFreqs, func(i, j int) bool {
-		// Если частота одинаковая, сравниваем слова лексикографически
-		if wordFreqs[i].count == wordFreqs[j].count {
-			return wordFreqs[i].word < wordFreqs[j].word // алфавитный порядок (A-Z)
-		}
-		// Иначе сортируем по частоте в порядке убывания (от большего к меньшему)
-		return wordFreqs[i].count > wordFreqs[j].count
+		return tieBreak.less(wordFreqs[i], wordFreqs[j])
 	})
 }
 
 // Извлекает первые n слов из отсортированного слайса.
-func extractTopWords(wordFreqs []wordFreq, n int) []string {
+func extractTopWords(wordFreqs []wordFreq, n int) []WordCount {
 	// Определяем размер результата: минимум из n и количества уникальных слов
 	resultSize := n
 	if len(wordFreqs) < n {
@@ -84,9 +192,9 @@ func extractTopWords(wordFreqs []wordFreq, n int) []string {
 	}
 
 	// Создаём итоговый слайс и заполняем его словами
-	result := make([]string, resultSize)
+	result := make([]WordCount, resultSize)
 	for i := 0; i < resultSize; i++ {
-		result[i] = wordFreqs[i].word
+		result[i] = WordCount{Word: wordFreqs[i].word, Count: wordFreqs[i].count}
 	}
 
 	return result
@@ -0,0 +1,59 @@
+package hw03frequencyanalysis
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// benchmarkText генерирует текст из words слов со словарём из unique слов
+// и распределением частот, похожим на естественный язык.
+func benchmarkText(words, unique int) string {
+	rnd := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rnd, 1.1, 1, uint64(unique-1))
+
+	var sb strings.Builder
+	for i := 0; i < words; i++ {
+		sb.WriteString("w")
+		sb.WriteString(strconv.FormatUint(zipf.Uint64(), 10))
+		sb.WriteByte(' ')
+	}
+	return sb.String()
+}
+
+func BenchmarkSelection(b *testing.B) {
+	frequency := buildFrequencyMap(strings.Fields(benchmarkText(1_000_000, 200_000)), newConfig(nil))
+
+	b.Run("sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			wordFreqs := mapToSlice(frequency)
+			sortByFrequency(wordFreqs, TieBreakLexicographic)
+			extractTopWords(wordFreqs, topN)
+		}
+	})
+
+	b.Run("heap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			extractTopWords(selectTopN(frequency, topN, TieBreakLexicographic), topN)
+		}
+	})
+}
+
+func BenchmarkTopN(b *testing.B) {
+	text := benchmarkText(1_000_000, 200_000)
+
+	b.Run("string", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			TopN(text, topN)
+		}
+	})
+
+	b.Run("reader", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := TopNFromReader(strings.NewReader(text), topN); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package hw03frequencyanalysis
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -127,3 +128,152 @@ func TestTop10(t *testing.T) {
 		require.Equal(t, expected, result)
 	})
 }
+
+func TestTop10NormalizedWords(t *testing.T) {
+	t.Run("positive test", func(t *testing.T) {
+		expected := []string{
+			"а",         // 8
+			"он",        // 8
+			"и",         // 6
+			"ты",        // 5
+			"что",       // 5
+			"в",         // 4
+			"его",       // 4
+			"если",      // 4
+			"кристофер", // 4
+			"не",        // 4
+		}
+		require.Equal(t, expected, Top10(text, WithNormalizedWords()))
+	})
+
+	t.Run("case and edge punctuation ignored", func(t *testing.T) {
+		result := Top10("Нога нога! нога, 'нога' рука", WithNormalizedWords())
+		require.Equal(t, []string{"нога", "рука"}, result)
+	})
+
+	t.Run("dash is not a word", func(t *testing.T) {
+		result := Top10("a - b - c -", WithNormalizedWords())
+		require.Equal(t, []string{"a", "b", "c"}, result)
+	})
+
+	t.Run("empty after normalization", func(t *testing.T) {
+		require.Len(t, Top10("- ! ... ,", WithNormalizedWords()), 0)
+	})
+}
+
+func TestNormalizeWord(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{input: "Нога", expected: "нога", ok: true},
+		{input: "нога!", expected: "нога", ok: true},
+		{input: "'нога'", expected: "нога", ok: true},
+		{input: "«Нога»,", expected: "нога", ok: true},
+		{input: "какой-то", expected: "какой-то", ok: true},
+		{input: "какойто", expected: "какойто", ok: true},
+		{input: "dog,cat", expected: "dog,cat", ok: true},
+		{input: "dog...cat", expected: "dog...cat", ok: true},
+		{input: "-------", expected: "-------", ok: true},
+		{input: "-", ok: false},
+		{input: "...", ok: false},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			result, ok := normalizeWord(tc.input)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestTopN(t *testing.T) {
+	t.Run("words with counts", func(t *testing.T) {
+		expected := []WordCount{
+			{Word: "он", Count: 8},
+			{Word: "а", Count: 6},
+			{Word: "и", Count: 6},
+		}
+		require.Equal(t, expected, TopN(text, 3))
+	})
+
+	t.Run("same path as Top10", func(t *testing.T) {
+		wordCounts := TopN(text, 10)
+		words := make([]string, 0, len(wordCounts))
+		for _, wc := range wordCounts {
+			words = append(words, wc.Word)
+		}
+		require.Equal(t, Top10(text), words)
+	})
+
+	t.Run("n greater than unique words", func(t *testing.T) {
+		result := TopN("b a b", 50)
+		require.Equal(t, []WordCount{{Word: "b", Count: 2}, {Word: "a", Count: 1}}, result)
+	})
+
+	t.Run("empty text or non-positive n", func(t *testing.T) {
+		require.Len(t, TopN("", 5), 0)
+		require.Len(t, TopN("a b c", 0), 0)
+		require.Len(t, TopN("a b c", -1), 0)
+	})
+
+	t.Run("normalized words", func(t *testing.T) {
+		result := TopN("Нога нога! рука -", 10, WithNormalizedWords())
+		require.Equal(t, []WordCount{{Word: "нога", Count: 2}, {Word: "рука", Count: 1}}, result)
+	})
+}
+
+func TestTopNTieBreak(t *testing.T) {
+	const text = "cherry apple banana apple cherry banana date"
+
+	tests := []struct {
+		name     string
+		tieBreak TieBreak
+		expected []string
+	}{
+		{
+			name:     "lexicographic",
+			tieBreak: TieBreakLexicographic,
+			expected: []string{"apple", "banana", "cherry", "date"},
+		},
+		{
+			name:     "reverse lexicographic",
+			tieBreak: TieBreakReverseLexicographic,
+			expected: []string{"cherry", "banana", "apple", "date"},
+		},
+		{
+			name:     "first occurrence",
+			tieBreak: TieBreakFirstOccurrence,
+			expected: []string{"cherry", "apple", "banana", "date"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Top10(text, WithTieBreak(tc.tieBreak)))
+		})
+	}
+}
+
+func TestTopNTokenizer(t *testing.T) {
+	t.Run("custom tokenizer", func(t *testing.T) {
+		commaTokenizer := TokenizerFunc(func(text string) []string {
+			return strings.Split(text, ",")
+		})
+		result := TopN("a,b b,a,c", 10, WithTokenizer(commaTokenizer))
+		expected := []WordCount{
+			{Word: "a", Count: 2},
+			{Word: "b b", Count: 1},
+			{Word: "c", Count: 1},
+		}
+		require.Equal(t, expected, result)
+	})
+
+	t.Run("nil tokenizer falls back to fields", func(t *testing.T) {
+		require.Equal(t, Top10(text), Top10(text, WithTokenizer(nil)))
+	})
+}
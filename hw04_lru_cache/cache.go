@@ -1,92 +1,90 @@
 package hw04lrucache
 
+import (
+	"time"
+
+	"github.com/Tapler/golang-diasoft-vseroev/hw04_lru_cache/lru"
+)
+
 type Key string
 
-type Cache interface {
-	Set(key Key, value interface{}) bool
-	Get(key Key) (interface{}, bool)
-	Clear()
+// Cache - LRU-кэш со строковыми ключами и значениями произвольного типа.
+// Сохранён для совместимости, типизированный кэш - lru.Cache[K, V].
+type Cache = lru.Cache[Key, interface{}]
+
+// Option настраивает кэш при создании.
+type Option = lru.Option[Key, interface{}]
+
+// Clock - источник текущего времени для проверки срока жизни элементов.
+type Clock = lru.Clock
+
+var (
+	// ErrItemTooLarge - стоимость элемента превышает ёмкость всего кэша.
+	ErrItemTooLarge = lru.ErrItemTooLarge
+	// ErrInvalidCost - функция стоимости вернула отрицательное значение.
+	ErrInvalidCost = lru.ErrInvalidCost
+)
+
+// PanicError - паника в функции загрузки GetOrLoad.
+type PanicError = lru.PanicError
+
+// EvictReason - причина удаления элемента из кэша.
+type EvictReason = lru.EvictReason
+
+// Stats - статистика работы кэша.
+type Stats = lru.Stats
+
+// Policy - политика вытеснения элементов из кэша.
+type Policy = lru.Policy
+
+// Политики вытеснения, см. lru.Policy.
+const (
+	PolicyLRU = lru.PolicyLRU
+	PolicyLFU = lru.PolicyLFU
+	Policy2Q  = lru.Policy2Q
+	PolicyARC = lru.PolicyARC
+)
+
+// NewCache создаёт новый LRU-кэш с заданной ёмкостью.
+func NewCache(capacity int, opts ...Option) Cache {
+	return lru.NewCache(capacity, opts...)
 }
 
-type lruCache struct {
-	capacity int               // максимальное количество элементов в кэше
-	queue    List              // очередь элементов (самые свежие в начале)
-	items    map[Key]*ListItem // словарь для быстрого поиска: ключ -> элемент очереди
+// NewCacheWithCost создаёт LRU-кэш, в котором суммарная стоимость элементов не превышает maxCost.
+func NewCacheWithCost(maxCost int, cost func(key Key, value interface{}) int, opts ...Option) Cache {
+	return lru.NewCacheWithCost(maxCost, cost, opts...)
 }
 
-type cacheItem struct {
-	key   Key
-	value interface{}
+// WithClock задаёт источник времени для проверки срока жизни элементов.
+func WithClock(clock Clock) Option {
+	return lru.WithClock[Key, interface{}](clock)
 }
 
-// NewCache создаёт новый LRU-кэш с заданной ёмкостью.
-func NewCache(capacity int) Cache {
-	return &lruCache{
-		capacity: capacity,
-		queue:    NewList(),
-		items:    make(map[Key]*ListItem, capacity),
-	}
+// WithJanitor запускает фоновое удаление элементов с истёкшим сроком жизни раз в interval.
+func WithJanitor(interval time.Duration) Option {
+	return lru.WithJanitor[Key, interface{}](interval)
+}
+
+// WithOnEvict задаёт функцию, вызываемую для каждого удалённого из кэша элемента.
+func WithOnEvict(onEvict func(key Key, value interface{}, reason EvictReason)) Option {
+	return lru.WithOnEvict(onEvict)
 }
 
-// Set добавляет значение в кэш по ключу.
-// Возвращаемое значение - флаг, присутствовал ли элемент в кэше.
-func (c *lruCache) Set(key Key, value interface{}) bool {
-	if item, ok := c.items[key]; ok {
-		// Случай 1: Элемент уже есть в кэше
-		// Обновляем его значение и перемещаем в начало очереди
-		item.Value = cacheItem{key: key, value: value}
-		c.queue.MoveToFront(item)
-		return true
-	}
-
-	// Случай 2: Элемента нет в кэше - добавляем новый
-	// Добавляем в начало очереди
-	item := c.queue.PushFront(cacheItem{key: key, value: value})
-	// Регистрируем в словаре для быстрого поиска
-	c.items[key] = item
-
-	// Проверяем, не превышена ли ёмкость кэша
-	if c.queue.Len() > c.capacity {
-		// Удаляем самый старый элемент (последний в очереди)
-		lastItem := c.queue.Back()
-		if lastItem != nil {
-			// Удаляем из очереди
-			c.queue.Remove(lastItem)
-			// Удаляем из словаря по ключу
-			lastCacheItem := lastItem.Value.(cacheItem)
-			delete(c.items, lastCacheItem.key)
-		}
-	}
-
-	return false
+// WithPolicy задаёт политику вытеснения. По умолчанию используется PolicyLRU.
+func WithPolicy(policy Policy) Option {
+	return lru.WithPolicy[Key, interface{}](policy)
 }
 
-// Get получает значение из кэша по ключу.
-// Возвращает значение и true, если элемент найден, иначе nil и false.
-// При успешном получении элемент перемещается в начало очереди.
-func (c *lruCache) Get(key Key) (interface{}, bool) {
-	if item, ok := c.items[key]; ok {
-		// Элемент найден в кэше
-		// Перемещаем в начало очереди
-		c.queue.MoveToFront(item)
-		// Извлекаем и возвращаем значение
-		cacheItem := item.Value.(cacheItem)
-		return cacheItem.value, true
-	}
-
-	// Элемент не найден в кэше
-	return nil, false
+// WithNegativeTTL включает запоминание ошибок загрузки в GetOrLoad на срок ttl.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return lru.WithNegativeTTL[Key, interface{}](ttl)
 }
 
-// Clear полностью очищает кэш, удаляя все элементы.
-func (c *lruCache) Clear() {
-	// обход всех элементов
-	for key := range c.items {
-		delete(c.items, key)
-	}
-
-	// удаление всех элементов списка
-	for c.queue.Len() > 0 {
-		c.queue.Remove(c.queue.Front())
-	}
+// Codec - формат, в котором Save и Load записывают и читают содержимое кэша.
+// Для GobCodec конкретные типы значений нужно зарегистрировать через gob.Register.
+type Codec = lru.Codec
+
+// WithCodec задаёт формат Save и Load. По умолчанию используется lru.GobCodec.
+func WithCodec(codec Codec) Option {
+	return lru.WithCodec[Key, interface{}](codec)
 }
@@ -1,11 +1,15 @@
 package hw04lrucache
 
 import (
+	"bytes"
+	"context"
 	"math/rand"
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/Tapler/golang-diasoft-vseroev/hw04_lru_cache/lru"
 	"github.com/stretchr/testify/require"
 )
 
@@ -144,8 +148,6 @@ func TestCache(t *testing.T) {
 }
 
 func TestCacheMultithreading(t *testing.T) {
-	t.Skip() // Remove me if task with asterisk completed.
-
 	c := NewCache(10)
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
@@ -166,3 +168,154 @@ func TestCacheMultithreading(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestCacheConcurrentStress(t *testing.T) {
+	caches := map[string]Cache{
+		"lru":     NewCache(100),
+		"sharded": NewShardedCache(100, 8),
+	}
+
+	for name, c := range caches {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			wg := &sync.WaitGroup{}
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < 10_000; i++ {
+						key := Key(strconv.Itoa(rand.Intn(200)))
+						switch i % 3 {
+						case 0:
+							c.Set(key, g)
+						case 1:
+							c.Get(key)
+						default:
+							// Clear тоже должен быть синхронизирован
+							if i%1000 == 2 {
+								c.Clear()
+							}
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+
+			// После гонок кэш остаётся рабочим
+			c.Set("final", 1)
+			val, ok := c.Get("final")
+			require.True(t, ok)
+			require.Equal(t, 1, val)
+		})
+	}
+}
+
+// stoppedClock - источник времени, который меняется только вручную.
+type stoppedClock struct {
+	now time.Time
+}
+
+func (c *stoppedClock) Now() time.Time {
+	return c.now
+}
+
+func TestCacheWithTTL(t *testing.T) {
+	clock := &stoppedClock{now: time.Unix(0, 0)}
+	c := NewCache(3, WithClock(clock))
+
+	c.SetWithTTL("a", 1, time.Minute)
+	val, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, val)
+
+	clock.now = clock.now.Add(time.Minute)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+}
+
+func TestCacheOnEvictAndStats(t *testing.T) {
+	var evicted []Key
+	c := NewCache(1, WithOnEvict(func(key Key, value interface{}, reason EvictReason) {
+		require.Equal(t, lru.EvictReasonCapacity, reason)
+		evicted = append(evicted, key)
+	}))
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	require.Equal(t, []Key{"a"}, evicted)
+	require.Equal(t, Stats{Misses: 1, Evictions: 1, Size: 1, Cost: 1}, c.Stats())
+}
+
+func TestCacheDeleteAndResize(t *testing.T) {
+	c := NewCache(3)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	require.True(t, c.Delete("b"))
+	require.Equal(t, []Key{"c", "a"}, c.Keys())
+
+	c.Resize(1)
+	require.Equal(t, []Key{"c"}, c.Keys())
+	require.Equal(t, 1, c.Len())
+}
+
+func TestCacheWithCost(t *testing.T) {
+	c := NewCacheWithCost(4, func(key Key, value interface{}) int {
+		return len(value.(string))
+	})
+
+	c.Set("a", "xx")
+	c.Set("b", "xx")
+	c.Set("c", "xx")
+	require.Equal(t, []Key{"c", "b"}, c.Keys())
+
+	_, err := c.TrySet("d", "xxxxx", 0)
+	require.ErrorIs(t, err, ErrItemTooLarge)
+}
+
+func TestCacheWithPolicy(t *testing.T) {
+	c := NewCache(2, WithPolicy(PolicyLFU))
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Get("b")
+	c.Get("b")
+	c.Set("c", 3) // вытесняет a, к которому обращались реже
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, []Key{"b", "c"}, c.Keys())
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	c := NewCache(2, WithNegativeTTL(time.Minute))
+	load := func(context.Context) (interface{}, error) { return 10, nil }
+
+	val, err := c.GetOrLoad(context.Background(), "a", load)
+	require.NoError(t, err)
+	require.Equal(t, 10, val)
+
+	val, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 10, val)
+}
+
+func TestCacheSaveLoad(t *testing.T) {
+	c := NewCache(3, WithCodec(lru.JSONCodec{}))
+	c.Set("a", "x")
+	c.Set("b", "y")
+	c.Get("a")
+
+	var buf bytes.Buffer
+	require.NoError(t, c.Save(&buf))
+
+	restored := NewCache(3, WithCodec(lru.JSONCodec{}))
+	require.NoError(t, restored.Load(&buf))
+	require.Equal(t, []Key{"a", "b"}, restored.Keys())
+
+	val, ok := restored.Get("b")
+	require.True(t, ok)
+	require.Equal(t, "y", val)
+}
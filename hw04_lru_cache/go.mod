@@ -1,8 +1,11 @@
 module github.com/Tapler/golang-diasoft-vseroev/hw04_lru_cache
 
-go 1.19
+go 1.23
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/stretchr/testify v1.7.0
+	go.uber.org/goleak v1.1.10
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
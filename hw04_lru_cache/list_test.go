@@ -137,3 +137,21 @@ func TestList(t *testing.T) {
 		require.Nil(t, l.Back())
 	})
 }
+
+func TestListIterators(t *testing.T) {
+	l := NewList()
+	mid := l.PushBack(2)
+	l.InsertBefore(1, mid)
+	l.InsertAfter(3, mid)
+	l.MoveToBack(l.Front()) // [2, 3, 1]
+
+	var elems []interface{}
+	for v := range l.All() {
+		elems = append(elems, v)
+	}
+	require.Equal(t, []interface{}{2, 3, 1}, elems)
+
+	// Элемент другого списка не удаляется и не меняет длину
+	l.Remove(NewList().PushBack(4))
+	require.Equal(t, 3, l.Len())
+}
@@ -0,0 +1,421 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrItemTooLarge - стоимость элемента превышает ёмкость всего кэша.
+	ErrItemTooLarge = errors.New("item cost exceeds cache capacity")
+	// ErrInvalidCost - функция стоимости вернула отрицательное значение.
+	ErrInvalidCost = errors.New("invalid item cost")
+)
+
+// Cache - кэш с ключами типа K и значениями типа V.
+// По умолчанию вытесняет самые давно использованные элементы (LRU), см. WithPolicy.
+type Cache[K comparable, V any] interface {
+	Set(key K, value V) bool
+	SetWithTTL(key K, value V, ttl time.Duration) bool
+	TrySet(key K, value V, ttl time.Duration) (bool, error)
+	Get(key K) (V, bool)
+	GetOrLoad(ctx context.Context, key K, load func(ctx context.Context) (V, error)) (V, error)
+	Peek(key K) (V, bool)
+	Delete(key K) bool
+	Keys() []K
+	Len() int
+	Resize(capacity int)
+	Clear()
+	Close()
+	Stats() Stats
+	Save(w io.Writer) error
+	Load(r io.Reader) error
+}
+
+// cache безопасен для использования из нескольких горутин:
+// все операции, включая Get (он меняет состояние политики вытеснения), выполняются под мьютексом.
+// Порядок вытеснения определяет policy, остальное (срок жизни, стоимость, статистика) - общее для всех политик.
+type cache[K comparable, V any] struct {
+	mu         sync.Mutex               // защищает политику и словарь
+	capacity   int                      // максимальная суммарная стоимость элементов в кэше
+	cost       func(key K, value V) int // стоимость элемента (nil - каждый элемент стоит 1)
+	used       int                      // суммарная стоимость элементов в кэше
+	policyKind Policy                   // политика вытеснения, выбранная через WithPolicy
+	policy     policy[K, V]             // порядок элементов и выбор вытесняемого
+	items      map[K]*entry[K, V]       // словарь для быстрого поиска: ключ -> элемент
+
+	clock           Clock         // источник времени для проверки срока жизни
+	janitorInterval time.Duration // период фоновой очистки (0 - без фоновой очистки)
+	stopJanitor     chan struct{} // закрывается в Close для остановки фоновой очистки
+	closeOnce       sync.Once
+
+	onEvict func(key K, value V, reason EvictReason) // вызывается для удалённых элементов
+	evicted []eviction[K, V]                         // удалённые под мьютексом, ещё не переданные в onEvict
+	stats   Stats                                    // счётчики попаданий, промахов и вытеснений
+
+	loads       map[K]*loadCall[V] // выполняющиеся загрузки GetOrLoad
+	failures    map[K]loadFailure  // запомненные ошибки загрузки
+	negativeTTL time.Duration      // срок хранения ошибок загрузки (0 - не хранить)
+
+	codec Codec // формат Save и Load
+}
+
+// entry - элемент кэша. Поля node, list и bucket принадлежат политике вытеснения.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	cost      int       // стоимость элемента, учитываемая в ёмкости кэша
+	expiresAt time.Time // момент истечения срока жизни (нулевое значение - бессрочно)
+
+	node   *ListItem[*entry[K, V]] // узел в списке политики
+	list   *entryList[K, V]        // список политики, в котором находится элемент
+	bucket *lfuBucket[K, V]        // группа элементов с одинаковой частотой обращений (LFU)
+}
+
+// expired проверяет, истёк ли срок жизни элемента к моменту now.
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// NewCache создаёт новый кэш, вмещающий capacity элементов.
+func NewCache[K comparable, V any](capacity int, opts ...Option[K, V]) Cache[K, V] {
+	c := &cache[K, V]{
+		capacity:    capacity,
+		items:       make(map[K]*entry[K, V], capacity),
+		loads:       make(map[K]*loadCall[V]),
+		failures:    make(map[K]loadFailure),
+		clock:       realClock{},
+		codec:       GobCodec{},
+		stopJanitor: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.policy = newPolicy[K, V](c.policyKind, capacity)
+
+	if c.janitorInterval > 0 {
+		go c.runJanitor()
+	}
+	return c
+}
+
+// NewCacheWithCost создаёт кэш, в котором суммарная стоимость элементов не превышает maxCost.
+// Стоимость элемента вычисляется функцией cost при добавлении, например как размер значения в байтах.
+// Элементы дороже maxCost не добавляются: TrySet возвращает для них ErrItemTooLarge.
+func NewCacheWithCost[K comparable, V any](
+	maxCost int, cost func(key K, value V) int, opts ...Option[K, V],
+) Cache[K, V] {
+	c := NewCache(maxCost, opts...)
+	c.(*cache[K, V]).cost = cost
+	return c
+}
+
+// Set добавляет значение в кэш по ключу без ограничения срока жизни.
+// Возвращаемое значение - флаг, присутствовал ли элемент в кэше.
+func (c *cache[K, V]) Set(key K, value V) bool {
+	return c.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL добавляет значение в кэш по ключу со сроком жизни ttl.
+// По истечении срока элемент считается отсутствующим. При ttl <= 0 срок не ограничен.
+// Возвращаемое значение - флаг, присутствовал ли элемент (с неистёкшим сроком) в кэше.
+// Элемент, который не помещается в кэш, не добавляется; причину можно узнать через TrySet.
+func (c *cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) bool {
+	wasAlive, _ := c.TrySet(key, value, ttl)
+	return wasAlive
+}
+
+// TrySet добавляет значение в кэш так же, как SetWithTTL, но сообщает, если элемент не добавлен:
+// ErrItemTooLarge - стоимость элемента больше ёмкости кэша, ErrInvalidCost - стоимость отрицательна.
+// Прежнее значение по ключу в этом случае удаляется, чтобы Get не вернул устаревшие данные.
+func (c *cache[K, V]) TrySet(key K, value V, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	return c.set(key, value, ttl)
+}
+
+// set добавляет значение в кэш. Вызывается под мьютексом.
+func (c *cache[K, V]) set(key K, value V, ttl time.Duration) (bool, error) {
+	// Новое значение заменяет запомненную ошибку загрузки и результат выполняющейся загрузки
+	delete(c.failures, key)
+	delete(c.loads, key)
+
+	now := c.clock.Now()
+	cost := 1
+	if c.cost != nil {
+		cost = c.cost(key, value)
+	}
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = now.Add(ttl)
+	}
+
+	e, ok := c.items[key]
+	wasAlive := ok && !e.expired(now)
+	if ok && !wasAlive {
+		// Истёкший элемент удаляем как истёкший и добавляем значение заново
+		c.remove(e, EvictReasonExpired)
+		ok = false
+	}
+
+	var err error
+	switch {
+	case cost < 0:
+		err = fmt.Errorf("%w: %d", ErrInvalidCost, cost)
+	case cost > c.capacity:
+		err = fmt.Errorf("%w: cost %d, capacity %d", ErrItemTooLarge, cost, c.capacity)
+	}
+	if err != nil {
+		if ok {
+			c.remove(e, EvictReasonCapacity)
+		}
+		return wasAlive, err
+	}
+
+	if ok {
+		// Случай 1: Элемент уже есть в кэше
+		// Обновляем его значение; для политики это обращение к элементу
+		c.used += cost - e.cost
+		e.list.setCost(e, cost)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.policy.access(e)
+	} else {
+		// Случай 2: Элемента нет в кэше - добавляем новый
+		e = &entry[K, V]{key: key, value: value, cost: cost, expiresAt: expiresAt}
+		c.items[key] = e
+		c.used += cost
+		c.policy.add(e)
+	}
+
+	// Проверяем, не превышена ли ёмкость кэша
+	c.evictOverflow(e)
+
+	return wasAlive, nil
+}
+
+// Get получает значение из кэша по ключу.
+// Возвращает значение и true, если элемент найден, иначе нулевое значение V и false.
+// При успешном получении политика учитывает обращение (для LRU элемент перемещается в начало очереди).
+// Элемент с истёкшим сроком жизни удаляется и считается отсутствующим.
+func (c *cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.unlock()
+
+	var zero V
+	e, ok := c.items[key]
+	if !ok {
+		// Элемент не найден в кэше
+		c.stats.Misses++
+		return zero, false
+	}
+
+	if e.expired(c.clock.Now()) {
+		// Срок жизни истёк - удаляем элемент
+		c.remove(e, EvictReasonExpired)
+		c.stats.Misses++
+		return zero, false
+	}
+
+	// Элемент найден в кэше
+	c.stats.Hits++
+	c.policy.access(e)
+	return e.value, true
+}
+
+// Peek получает значение из кэша по ключу, не меняя его положения в очереди и статистики.
+func (c *cache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok || e.expired(c.clock.Now()) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Delete удаляет элемент из кэша по ключу.
+// Возвращаемое значение - флаг, присутствовал ли элемент (с неистёкшим сроком) в кэше.
+func (c *cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.unlock()
+
+	delete(c.failures, key)
+	delete(c.loads, key)
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	if e.expired(c.clock.Now()) {
+		c.remove(e, EvictReasonExpired)
+		return false
+	}
+	c.remove(e, EvictReasonDeleted)
+	return true
+}
+
+// Keys возвращает ключи элементов с неистёкшим сроком жизни: первым идёт элемент,
+// который политика вытеснит последним. Для LRU это порядок от самого недавно
+// использованного к самому давнему.
+func (c *cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	keys := make([]K, 0, len(c.items))
+	c.policy.each(func(e *entry[K, V]) {
+		if !e.expired(now) {
+			keys = append(keys, e.key)
+		}
+	})
+	return keys
+}
+
+// Len возвращает количество элементов в кэше, включая истёкшие, но ещё не удалённые.
+func (c *cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.items)
+}
+
+// Resize меняет ёмкость кэша (для NewCacheWithCost - максимальную суммарную стоимость).
+// Если элементы не помещаются в новую ёмкость, лишние сразу вытесняются.
+// Отрицательная ёмкость считается нулевой.
+func (c *cache[K, V]) Resize(capacity int) {
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	c.mu.Lock()
+	defer c.unlock()
+
+	c.capacity = capacity
+	c.policy.resize(capacity)
+	c.evictOverflow(nil)
+}
+
+// Clear полностью очищает кэш, удаляя все элементы.
+func (c *cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.unlock()
+
+	c.policy.each(func(e *entry[K, V]) {
+		c.forget(e, EvictReasonCleared)
+	})
+	c.policy.clear()
+	c.failures = make(map[K]loadFailure)
+	c.loads = make(map[K]*loadCall[V])
+}
+
+// Stats возвращает статистику работы кэша.
+func (c *cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = len(c.items)
+	stats.Cost = c.used
+	return stats
+}
+
+// Close останавливает фоновую очистку, если она была запущена через WithJanitor.
+// Кэш остаётся рабочим; повторные вызовы ничего не делают.
+func (c *cache[K, V]) Close() {
+	c.closeOnce.Do(func() {
+		close(c.stopJanitor)
+	})
+}
+
+// remove удаляет элемент из политики и словаря. Вызывается под мьютексом.
+func (c *cache[K, V]) remove(e *entry[K, V], reason EvictReason) {
+	c.policy.remove(e)
+	c.forget(e, reason)
+}
+
+// forget удаляет из словаря элемент, уже убранный из политики, и запоминает его для onEvict.
+// Вызывается под мьютексом.
+func (c *cache[K, V]) forget(e *entry[K, V], reason EvictReason) {
+	delete(c.items, e.key)
+	c.used -= e.cost
+
+	if reason == EvictReasonCapacity || reason == EvictReasonExpired {
+		c.stats.Evictions++
+	}
+	if c.onEvict != nil {
+		c.evicted = append(c.evicted, eviction[K, V]{key: e.key, value: e.value, reason: reason})
+	}
+}
+
+// evictOverflow вытесняет выбранные политикой элементы, пока их суммарная стоимость
+// превышает ёмкость. Элемент keep, только что добавленный или обновлённый, вытесняется
+// лишь если других элементов не осталось. Вызывается под мьютексом.
+func (c *cache[K, V]) evictOverflow(keep *entry[K, V]) {
+	for c.used > c.capacity {
+		victim := c.policy.evict(keep)
+		if victim == nil {
+			victim = keep
+			c.policy.remove(victim)
+		}
+		c.forget(victim, EvictReasonCapacity)
+	}
+}
+
+// unlock снимает блокировку и передаёт в onEvict элементы, удалённые под ней.
+// onEvict вызывается без блокировки, чтобы он мог обращаться к кэшу.
+func (c *cache[K, V]) unlock() {
+	evicted := c.evicted
+	c.evicted = nil
+	c.mu.Unlock()
+
+	for _, e := range evicted {
+		c.onEvict(e.key, e.value, e.reason)
+	}
+}
+
+// removeExpired удаляет все элементы с истёкшим сроком жизни.
+func (c *cache[K, V]) removeExpired() {
+	c.mu.Lock()
+	defer c.unlock()
+
+	// Сначала собираем истёкшие элементы: удалять их во время обхода политики нельзя
+	now := c.clock.Now()
+	var expired []*entry[K, V]
+	c.policy.each(func(e *entry[K, V]) {
+		if e.expired(now) {
+			expired = append(expired, e)
+		}
+	})
+	for _, e := range expired {
+		c.remove(e, EvictReasonExpired)
+	}
+
+	for key, failure := range c.failures {
+		if failure.expired(now) {
+			delete(c.failures, key)
+		}
+	}
+}
+
+// runJanitor периодически удаляет элементы с истёкшим сроком жизни до вызова Close.
+func (c *cache[K, V]) runJanitor() {
+	ticker := time.NewTicker(c.janitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopJanitor:
+			return
+		case <-ticker.C:
+			c.removeExpired()
+		}
+	}
+}
@@ -0,0 +1,590 @@
+package lru
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestCache(t *testing.T) {
+	t.Run("empty cache", func(t *testing.T) {
+		c := NewCache[string, int](10)
+
+		_, ok := c.Get("aaa")
+		require.False(t, ok)
+
+		_, ok = c.Get("bbb")
+		require.False(t, ok)
+	})
+
+	t.Run("simple", func(t *testing.T) {
+		c := NewCache[string, int](5)
+
+		wasInCache := c.Set("aaa", 100)
+		require.False(t, wasInCache)
+
+		wasInCache = c.Set("bbb", 200)
+		require.False(t, wasInCache)
+
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 100, val)
+
+		val, ok = c.Get("bbb")
+		require.True(t, ok)
+		require.Equal(t, 200, val)
+
+		wasInCache = c.Set("aaa", 300)
+		require.True(t, wasInCache)
+
+		val, ok = c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 300, val)
+
+		val, ok = c.Get("ccc")
+		require.False(t, ok)
+		require.Zero(t, val)
+	})
+
+	t.Run("purge logic", func(t *testing.T) {
+		// Тест на выталкивание элементов из-за размера очереди
+		c := NewCache[string, int](3)
+
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Set("c", 3)
+
+		// Все элементы должны быть в кэше
+		val, ok := c.Get("a")
+		require.True(t, ok)
+		require.Equal(t, 1, val)
+
+		val, ok = c.Get("b")
+		require.True(t, ok)
+		require.Equal(t, 2, val)
+
+		val, ok = c.Get("c")
+		require.True(t, ok)
+		require.Equal(t, 3, val)
+
+		// Добавляем 4-й элемент - первый должен вытолкнуться
+		c.Set("d", 4)
+
+		// Элемент "a" должен быть вытолкнут
+		_, ok = c.Get("a")
+		require.False(t, ok)
+
+		// Остальные элементы должны быть в кэше
+		val, ok = c.Get("b")
+		require.True(t, ok)
+		require.Equal(t, 2, val)
+
+		val, ok = c.Get("c")
+		require.True(t, ok)
+		require.Equal(t, 3, val)
+
+		val, ok = c.Get("d")
+		require.True(t, ok)
+		require.Equal(t, 4, val)
+	})
+
+	t.Run("purge least recently used", func(t *testing.T) {
+		c := NewCache[string, int](3)
+
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Set("c", 3)
+
+		// Обращаемся к элементам в разном порядке
+		c.Get("a")     // a становится самым свежим
+		c.Set("b", 20) // b обновляется и становится самым свежим
+		c.Get("a")     // a снова становится самым свежим
+
+		// Добавляем новый элемент - c должен вытолкнуться
+		c.Set("d", 4)
+		_, ok := c.Get("c")
+		require.False(t, ok)
+
+		// Остальные элементы должны быть в кэше
+		val, ok := c.Get("a")
+		require.True(t, ok)
+		require.Equal(t, 1, val)
+
+		val, ok = c.Get("b")
+		require.True(t, ok)
+		require.Equal(t, 20, val)
+
+		val, ok = c.Get("d")
+		require.True(t, ok)
+		require.Equal(t, 4, val)
+	})
+
+	t.Run("clear cache", func(t *testing.T) {
+		c := NewCache[string, int](3)
+
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Set("c", 3)
+
+		c.Clear()
+
+		// Все элементы должны быть удалены
+		_, ok := c.Get("a")
+		require.False(t, ok)
+
+		_, ok = c.Get("b")
+		require.False(t, ok)
+
+		_, ok = c.Get("c")
+		require.False(t, ok)
+	})
+}
+
+func TestCacheMultithreading(t *testing.T) {
+	c := NewCache[string, int](10)
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1_000_000; i++ {
+			c.Set(strconv.Itoa(i), i)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1_000_000; i++ {
+			c.Get(strconv.Itoa(rand.Intn(1_000_000)))
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestCacheConcurrentStress(t *testing.T) {
+	caches := map[string]Cache[string, int]{
+		"lru":     NewCache[string, int](100),
+		"sharded": NewShardedCache[string, int](100, 8, hashString),
+	}
+
+	for name, c := range caches {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			wg := &sync.WaitGroup{}
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < 10_000; i++ {
+						key := strconv.Itoa(rand.Intn(200))
+						switch i % 3 {
+						case 0:
+							c.Set(key, g)
+						case 1:
+							c.Get(key)
+						default:
+							// Clear тоже должен быть синхронизирован
+							if i%1000 == 2 {
+								c.Clear()
+							}
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+
+			// После гонок кэш остаётся рабочим
+			c.Set("final", 1)
+			val, ok := c.Get("final")
+			require.True(t, ok)
+			require.Equal(t, 1, val)
+		})
+	}
+}
+
+func TestCacheTypedValues(t *testing.T) {
+	type user struct {
+		name string
+		age  int
+	}
+
+	c := NewCache[int, *user](2)
+	c.Set(1, &user{name: "alice", age: 30})
+	c.Set(2, &user{name: "bob", age: 25})
+
+	// Значение возвращается без приведения типа
+	u, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "alice", u.name)
+
+	c.Set(3, &user{name: "carol", age: 40})
+	u, ok = c.Get(2)
+	require.False(t, ok)
+	require.Nil(t, u)
+}
+
+// fakeClock - управляемый источник времени для тестов срока жизни.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestCacheTTL(t *testing.T) {
+	t.Run("lazy expiry on get", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock[string, int](clock))
+
+		c.SetWithTTL("a", 1, time.Minute)
+		c.Set("b", 2)
+
+		clock.Advance(59 * time.Second)
+		val, ok := c.Get("a")
+		require.True(t, ok)
+		require.Equal(t, 1, val)
+
+		clock.Advance(time.Second)
+		_, ok = c.Get("a")
+		require.False(t, ok)
+
+		// Элемент без срока жизни не истекает
+		clock.Advance(24 * time.Hour)
+		val, ok = c.Get("b")
+		require.True(t, ok)
+		require.Equal(t, 2, val)
+	})
+
+	t.Run("set over expired item", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock[string, int](clock))
+
+		c.SetWithTTL("a", 1, time.Second)
+		clock.Advance(time.Second)
+
+		// Истёкший элемент считается отсутствующим
+		require.False(t, c.SetWithTTL("a", 2, time.Second))
+		require.True(t, c.Set("a", 3))
+
+		// Set без срока снимает ограничение
+		clock.Advance(time.Hour)
+		val, ok := c.Get("a")
+		require.True(t, ok)
+		require.Equal(t, 3, val)
+	})
+
+	t.Run("non-positive ttl means no expiry", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock[string, int](clock))
+
+		c.SetWithTTL("a", 1, 0)
+		c.SetWithTTL("b", 2, -time.Second)
+		clock.Advance(time.Hour)
+
+		_, ok := c.Get("a")
+		require.True(t, ok)
+		_, ok = c.Get("b")
+		require.True(t, ok)
+	})
+
+	t.Run("remove expired", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock[string, int](clock)).(*cache[string, int])
+
+		c.SetWithTTL("a", 1, time.Second)
+		c.SetWithTTL("b", 2, time.Minute)
+		c.Set("c", 3)
+		clock.Advance(time.Second)
+
+		c.removeExpired()
+		require.Len(t, c.items, 2)
+		require.NotContains(t, c.items, "a")
+	})
+
+	t.Run("janitor", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock[string, int](clock), WithJanitor[string, int](time.Millisecond))
+		defer c.Close()
+
+		c.SetWithTTL("a", 1, time.Second)
+		c.Set("b", 2)
+		clock.Advance(time.Second)
+
+		// Фоновая очистка удаляет элемент без обращения к нему
+		lc := c.(*cache[string, int])
+		require.Eventually(t, func() bool {
+			lc.mu.Lock()
+			defer lc.mu.Unlock()
+			return len(lc.items) == 1
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("close is idempotent", func(t *testing.T) {
+		defer goleak.VerifyNone(t)
+
+		c := NewCache(10, WithJanitor[string, int](time.Millisecond))
+		c.Close()
+		c.Close()
+
+		// Кэш без фоновой очистки тоже можно закрыть
+		NewCache[string, int](10).Close()
+	})
+
+	t.Run("sharded", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewShardedCache(10, 3, hashString, WithClock[string, int](clock))
+		defer c.Close()
+
+		c.SetWithTTL("a", 1, time.Second)
+		clock.Advance(time.Second)
+		_, ok := c.Get("a")
+		require.False(t, ok)
+	})
+}
+
+type evicted struct {
+	key    string
+	value  int
+	reason EvictReason
+}
+
+func TestCacheOnEvict(t *testing.T) {
+	t.Run("reasons", func(t *testing.T) {
+		clock := newFakeClock()
+		var got []evicted
+		c := NewCache(2, WithClock[string, int](clock), WithOnEvict(func(key string, value int, reason EvictReason) {
+			got = append(got, evicted{key, value, reason})
+		}))
+
+		c.Set("aaa", 1)
+		c.Set("bbb", 2)
+		c.Set("ccc", 3) // вытесняет aaa
+		require.Equal(t, []evicted{{"aaa", 1, EvictReasonCapacity}}, got)
+
+		c.SetWithTTL("bbb", 20, time.Second)
+		clock.Advance(2 * time.Second)
+		_, ok := c.Get("bbb")
+		require.False(t, ok)
+		require.Equal(t, evicted{"bbb", 20, EvictReasonExpired}, got[1])
+
+		c.Clear()
+		require.Equal(t, evicted{"ccc", 3, EvictReasonCleared}, got[2])
+		require.Len(t, got, 3)
+	})
+
+	t.Run("set over expired item", func(t *testing.T) {
+		clock := newFakeClock()
+		var got []evicted
+		c := NewCache(2, WithClock[string, int](clock), WithOnEvict(func(key string, value int, reason EvictReason) {
+			got = append(got, evicted{key, value, reason})
+		}))
+
+		c.SetWithTTL("aaa", 1, time.Second)
+		clock.Advance(2 * time.Second)
+		require.False(t, c.Set("aaa", 2))
+		require.Equal(t, []evicted{{"aaa", 1, EvictReasonExpired}}, got)
+		require.Equal(t, Stats{Evictions: 1, Size: 1, Cost: 1}, c.Stats())
+
+		// Новое значение добавлено без срока жизни прежнего
+		clock.Advance(time.Hour)
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 2, val)
+	})
+
+	t.Run("callback may use cache", func(t *testing.T) {
+		var c Cache[string, int]
+		c = NewCache(1, WithOnEvict(func(key string, value int, reason EvictReason) {
+			c.Get(key)
+		}))
+
+		c.Set("aaa", 1)
+		c.Set("bbb", 2)
+		_, ok := c.Get("bbb")
+		require.True(t, ok)
+	})
+}
+
+func TestCacheStats(t *testing.T) {
+	clock := newFakeClock()
+	c := NewCache(2, WithClock[string, int](clock))
+
+	c.Set("aaa", 1)
+	c.Set("bbb", 2)
+	c.Get("aaa")
+	c.Get("zzz")
+	c.Set("ccc", 3) // вытесняет bbb
+	require.Equal(t, Stats{Hits: 1, Misses: 1, Evictions: 1, Size: 2, Cost: 2}, c.Stats())
+
+	c.SetWithTTL("ddd", 4, time.Second)
+	clock.Advance(2 * time.Second)
+	c.Get("ddd")
+	require.Equal(t, Stats{Hits: 1, Misses: 2, Evictions: 3, Size: 1, Cost: 1}, c.Stats())
+
+	c.Clear()
+	require.Equal(t, Stats{Hits: 1, Misses: 2, Evictions: 3, Size: 0, Cost: 0}, c.Stats())
+}
+
+func TestCacheDeletePeekKeys(t *testing.T) {
+	t.Run("delete", func(t *testing.T) {
+		var got []evicted
+		c := NewCache(3, WithOnEvict(func(key string, value int, reason EvictReason) {
+			got = append(got, evicted{key, value, reason})
+		}))
+		c.Set("aaa", 1)
+		c.Set("bbb", 2)
+
+		require.True(t, c.Delete("aaa"))
+		require.False(t, c.Delete("aaa"))
+		require.False(t, c.Delete("zzz"))
+		require.Equal(t, []evicted{{"aaa", 1, EvictReasonDeleted}}, got)
+		require.Equal(t, []string{"bbb"}, c.Keys())
+		require.Equal(t, 1, c.Len())
+	})
+
+	t.Run("peek does not promote", func(t *testing.T) {
+		c := NewCache[string, int](2)
+		c.Set("aaa", 1)
+		c.Set("bbb", 2)
+
+		val, ok := c.Peek("aaa")
+		require.True(t, ok)
+		require.Equal(t, 1, val)
+		_, ok = c.Peek("zzz")
+		require.False(t, ok)
+		require.Equal(t, Stats{Size: 2, Cost: 2}, c.Stats())
+
+		c.Set("ccc", 3) // aaa остаётся самым давним и вытесняется
+		_, ok = c.Peek("aaa")
+		require.False(t, ok)
+	})
+
+	t.Run("keys in recency order", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(5, WithClock[string, int](clock))
+		c.Set("aaa", 1)
+		c.Set("bbb", 2)
+		c.SetWithTTL("ccc", 3, time.Second)
+		c.Set("ddd", 4)
+		c.Get("aaa")
+
+		require.Equal(t, []string{"aaa", "ddd", "ccc", "bbb"}, c.Keys())
+
+		clock.Advance(time.Second)
+		require.Equal(t, []string{"aaa", "ddd", "bbb"}, c.Keys())
+		_, ok := c.Peek("ccc")
+		require.False(t, ok)
+	})
+}
+
+func TestCacheResize(t *testing.T) {
+	var got []string
+	c := NewCache(5, WithOnEvict(func(key string, value int, reason EvictReason) {
+		require.Equal(t, EvictReasonCapacity, reason)
+		got = append(got, key)
+	}))
+	for i := 0; i < 5; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	c.Resize(2)
+	require.Equal(t, []string{"0", "1", "2"}, got)
+	require.Equal(t, []string{"4", "3"}, c.Keys())
+
+	c.Resize(3)
+	c.Set("5", 5)
+	require.Equal(t, []string{"5", "4", "3"}, c.Keys())
+
+	c.Resize(-1)
+	require.Equal(t, 0, c.Len())
+	c.Set("6", 6)
+	require.Equal(t, 0, c.Len())
+}
+
+func TestCacheWithCost(t *testing.T) {
+	byLen := func(key string, value string) int { return len(value) }
+
+	t.Run("evicts until fits", func(t *testing.T) {
+		var got []string
+		c := NewCacheWithCost(10, byLen, WithOnEvict(func(key string, value string, reason EvictReason) {
+			got = append(got, key)
+		}))
+
+		c.Set("a", "xxx")
+		c.Set("b", "xxx")
+		c.Set("c", "xxx")
+		require.Equal(t, 9, c.Stats().Cost)
+
+		c.Set("d", "xxxxxx") // вытесняет a и b
+		require.Equal(t, []string{"a", "b"}, got)
+		require.Equal(t, []string{"d", "c"}, c.Keys())
+		require.Equal(t, 9, c.Stats().Cost)
+
+		c.Set("c", "x") // уменьшение стоимости существующего элемента
+		require.Equal(t, 7, c.Stats().Cost)
+		c.Set("d", "xxxxxxxxxx") // увеличение стоимости вытесняет c
+		require.Equal(t, []string{"d"}, c.Keys())
+		require.Equal(t, 10, c.Stats().Cost)
+	})
+
+	t.Run("rejects too large item", func(t *testing.T) {
+		c := NewCacheWithCost(5, byLen)
+		c.Set("a", "xx")
+		c.Set("b", "xx")
+
+		wasInCache, err := c.TrySet("c", "xxxxxx", 0)
+		require.ErrorIs(t, err, ErrItemTooLarge)
+		require.Contains(t, err.Error(), "cost 6, capacity 5")
+		require.False(t, wasInCache)
+		require.Equal(t, []string{"b", "a"}, c.Keys())
+
+		// Устаревшее значение по ключу удаляется
+		require.True(t, c.Set("a", "xxxxxx"))
+		_, ok := c.Get("a")
+		require.False(t, ok)
+		require.Equal(t, 2, c.Stats().Cost)
+
+		wasInCache, err = c.TrySet("b", "x", 0)
+		require.NoError(t, err)
+		require.True(t, wasInCache)
+	})
+
+	t.Run("rejects negative cost", func(t *testing.T) {
+		c := NewCacheWithCost(5, func(key string, value int) int { return value })
+
+		_, err := c.TrySet("a", -1, 0)
+		require.ErrorIs(t, err, ErrInvalidCost)
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("resize", func(t *testing.T) {
+		c := NewCacheWithCost(10, byLen)
+		c.Set("a", "xxxx")
+		c.Set("b", "xxxx")
+
+		c.Resize(5)
+		require.Equal(t, []string{"b"}, c.Keys())
+		_, err := c.TrySet("c", "xxxxxx", 0)
+		require.ErrorIs(t, err, ErrItemTooLarge)
+	})
+}
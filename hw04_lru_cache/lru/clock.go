@@ -0,0 +1,16 @@
+package lru
+
+import "time"
+
+// Clock - источник текущего времени для проверки срока жизни элементов.
+// Позволяет подменять время в тестах.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock - системное время.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,232 @@
+package lru
+
+import "iter"
+
+type List[T any] interface {
+	Len() int
+	Front() *ListItem[T]
+	Back() *ListItem[T]
+	PushFront(v T) *ListItem[T]
+	PushBack(v T) *ListItem[T]
+	PushFrontList(other List[T])
+	PushBackList(other List[T])
+	InsertBefore(v T, mark *ListItem[T]) *ListItem[T]
+	InsertAfter(v T, mark *ListItem[T]) *ListItem[T]
+	Remove(i *ListItem[T])
+	MoveToFront(i *ListItem[T])
+	MoveToBack(i *ListItem[T])
+	All() iter.Seq[T]
+	Backward() iter.Seq[T]
+	Items() iter.Seq[*ListItem[T]]
+}
+
+type ListItem[T any] struct {
+	Value T
+	Next  *ListItem[T]
+	Prev  *ListItem[T]
+	list  *list[T] // список, которому принадлежит элемент (nil - элемент удалён)
+}
+
+type list[T any] struct {
+	front *ListItem[T] // первый элемент списка (голова)
+	back  *ListItem[T] // последний элемент списка (хвост)
+	len   int          // текущая длина списка
+}
+
+// NewList создаёт новый пустой двусвязный список.
+// Операции с элементами, которые не принадлежат списку (удалены из него
+// или взяты из другого списка), ничего не делают.
+func NewList[T any]() List[T] {
+	return new(list[T])
+}
+
+// Len возвращает длину списка.
+func (l *list[T]) Len() int {
+	return l.len
+}
+
+// Front возвращает первый элемент списка.
+func (l *list[T]) Front() *ListItem[T] {
+	return l.front
+}
+
+// Back возвращает последний элемент списка.
+func (l *list[T]) Back() *ListItem[T] {
+	return l.back
+}
+
+// PushFront добавляет значение в начало списка.
+func (l *list[T]) PushFront(v T) *ListItem[T] {
+	return l.link(&ListItem[T]{Value: v}, nil, l.front)
+}
+
+// PushBack добавляет значение в конец списка.
+func (l *list[T]) PushBack(v T) *ListItem[T] {
+	return l.link(&ListItem[T]{Value: v}, l.back, nil)
+}
+
+// PushFrontList добавляет копии значений другого списка в начало списка, сохраняя их порядок.
+// other может совпадать с самим списком.
+func (l *list[T]) PushFrontList(other List[T]) {
+	// Длину запоминаем заранее, чтобы при other == l не обходить добавленные элементы
+	for n, i := other.Len(), other.Back(); n > 0; n, i = n-1, i.Prev {
+		l.PushFront(i.Value)
+	}
+}
+
+// PushBackList добавляет копии значений другого списка в конец списка, сохраняя их порядок.
+// other может совпадать с самим списком.
+func (l *list[T]) PushBackList(other List[T]) {
+	for n, i := other.Len(), other.Front(); n > 0; n, i = n-1, i.Next {
+		l.PushBack(i.Value)
+	}
+}
+
+// InsertBefore добавляет значение перед элементом mark и возвращает новый элемент.
+// Если mark не принадлежит списку, список не меняется и возвращается nil.
+func (l *list[T]) InsertBefore(v T, mark *ListItem[T]) *ListItem[T] {
+	if !l.owns(mark) {
+		return nil
+	}
+	return l.link(&ListItem[T]{Value: v}, mark.Prev, mark)
+}
+
+// InsertAfter добавляет значение после элемента mark и возвращает новый элемент.
+// Если mark не принадлежит списку, список не меняется и возвращается nil.
+func (l *list[T]) InsertAfter(v T, mark *ListItem[T]) *ListItem[T] {
+	if !l.owns(mark) {
+		return nil
+	}
+	return l.link(&ListItem[T]{Value: v}, mark, mark.Next)
+}
+
+// Remove удаляет элемент из списка.
+// Элемент, не принадлежащий списку, игнорируется.
+func (l *list[T]) Remove(i *ListItem[T]) {
+	if !l.owns(i) {
+		return
+	}
+
+	l.unlink(i)
+	i.list = nil
+}
+
+// MoveToFront перемещает элемент в начало списка.
+// Элемент, не принадлежащий списку, игнорируется.
+func (l *list[T]) MoveToFront(i *ListItem[T]) {
+	// если элемент уже в начале, ничего не делаем
+	if !l.owns(i) || l.front == i {
+		return
+	}
+
+	l.unlink(i)
+	l.link(i, nil, l.front)
+}
+
+// MoveToBack перемещает элемент в конец списка.
+// Элемент, не принадлежащий списку, игнорируется.
+func (l *list[T]) MoveToBack(i *ListItem[T]) {
+	// если элемент уже в конце, ничего не делаем
+	if !l.owns(i) || l.back == i {
+		return
+	}
+
+	l.unlink(i)
+	l.link(i, l.back, nil)
+}
+
+// All возвращает итератор по значениям от начала списка к концу.
+func (l *list[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range l.Items() {
+			if !yield(i.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward возвращает итератор по значениям от конца списка к началу.
+func (l *list[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := l.back; i != nil; {
+			// Запоминаем предыдущий элемент до того, как текущий могут удалить
+			prev := i.Prev
+			if !yield(i.Value) {
+				return
+			}
+			i = prev
+		}
+	}
+}
+
+// Items возвращает итератор по элементам от начала списка к концу.
+// Текущий элемент можно удалить или переместить во время обхода.
+func (l *list[T]) Items() iter.Seq[*ListItem[T]] {
+	return func(yield func(*ListItem[T]) bool) {
+		for i := l.front; i != nil; {
+			// Запоминаем следующий элемент до того, как текущий могут удалить
+			next := i.Next
+			if !yield(i) {
+				return
+			}
+			i = next
+		}
+	}
+}
+
+// owns проверяет, что элемент принадлежит списку.
+func (l *list[T]) owns(i *ListItem[T]) bool {
+	return i != nil && i.list == l
+}
+
+// link вставляет элемент между prev и next и возвращает его.
+// nil вместо prev или next означает начало или конец списка.
+func (l *list[T]) link(i, prev, next *ListItem[T]) *ListItem[T] {
+	i.Prev = prev
+	i.Next = next
+	i.list = l
+
+	if prev != nil {
+		// Если есть предыдущий элемент, связываем его с новым
+		prev.Next = i
+	} else {
+		// Иначе новый элемент становится первым
+		l.front = i
+	}
+
+	if next != nil {
+		// Если есть следующий элемент, связываем его с новым
+		next.Prev = i
+	} else {
+		// Иначе новый элемент становится последним
+		l.back = i
+	}
+
+	l.len++
+	return i
+}
+
+// unlink отвязывает элемент от соседей и связывает их между собой.
+func (l *list[T]) unlink(i *ListItem[T]) {
+	if i.Prev != nil {
+		// Если есть предыдущий элемент, связываем его со следующим
+		i.Prev.Next = i.Next
+	} else {
+		// Если удаляем первый элемент, обновляем указатель front
+		l.front = i.Next
+	}
+
+	if i.Next != nil {
+		// Если есть следующий элемент, связываем его с предыдущим
+		i.Next.Prev = i.Prev
+	} else {
+		// Если удаляем последний элемент, обновляем указатель back
+		l.back = i.Prev
+	}
+
+	// Обнуляем ссылки, чтобы удалённый элемент не удерживал соседей в памяти
+	i.Prev = nil
+	i.Next = nil
+	l.len--
+}
@@ -0,0 +1,244 @@
+package lru
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	t.Run("empty list", func(t *testing.T) {
+		l := NewList[int]()
+
+		require.Equal(t, 0, l.Len())
+		require.Nil(t, l.Front())
+		require.Nil(t, l.Back())
+	})
+
+	t.Run("complex", func(t *testing.T) {
+		l := NewList[int]()
+
+		l.PushFront(10) // [10]
+		l.PushBack(20)  // [10, 20]
+		l.PushBack(30)  // [10, 20, 30]
+		require.Equal(t, 3, l.Len())
+
+		middle := l.Front().Next // 20
+		l.Remove(middle)         // [10, 30]
+		require.Equal(t, 2, l.Len())
+
+		for i, v := range [...]int{40, 50, 60, 70, 80} {
+			if i%2 == 0 {
+				l.PushFront(v)
+			} else {
+				l.PushBack(v)
+			}
+		} // [80, 60, 40, 10, 30, 50, 70]
+
+		require.Equal(t, 7, l.Len())
+		require.Equal(t, 80, l.Front().Value)
+		require.Equal(t, 70, l.Back().Value)
+
+		l.MoveToFront(l.Front()) // [80, 60, 40, 10, 30, 50, 70]
+		l.MoveToFront(l.Back())  // [70, 80, 60, 40, 10, 30, 50]
+
+		elems := make([]int, 0, l.Len())
+		for i := l.Front(); i != nil; i = i.Next {
+			elems = append(elems, i.Value)
+		}
+		require.Equal(t, []int{70, 80, 60, 40, 10, 30, 50}, elems)
+	})
+
+	t.Run("remove single element", func(t *testing.T) {
+		l := NewList[int]()
+
+		item := l.PushFront(10)
+		require.Equal(t, 1, l.Len())
+		require.Equal(t, 10, l.Front().Value)
+		require.Equal(t, 10, l.Back().Value)
+
+		l.Remove(item)
+		require.Equal(t, 0, l.Len())
+		require.Nil(t, l.Front())
+		require.Nil(t, l.Back())
+	})
+
+	t.Run("remove first element", func(t *testing.T) {
+		l := NewList[int]()
+
+		l.PushBack(10)
+		l.PushBack(20)
+		l.PushBack(30) // [10, 20, 30]
+		require.Equal(t, 3, l.Len())
+
+		l.Remove(l.Front()) // [20, 30]
+		require.Equal(t, 2, l.Len())
+		require.Equal(t, 20, l.Front().Value)
+		require.Equal(t, 30, l.Back().Value)
+	})
+
+	t.Run("remove last element", func(t *testing.T) {
+		l := NewList[int]()
+
+		l.PushBack(10)
+		l.PushBack(20)
+		l.PushBack(30) // [10, 20, 30]
+		require.Equal(t, 3, l.Len())
+
+		l.Remove(l.Back()) // [10, 20]
+		require.Equal(t, 2, l.Len())
+		require.Equal(t, 10, l.Front().Value)
+		require.Equal(t, 20, l.Back().Value)
+	})
+
+	t.Run("move to front already front", func(t *testing.T) {
+		l := NewList[int]()
+
+		l.PushBack(10)
+		l.PushBack(20)
+		l.PushBack(30) // [10, 20, 30]
+
+		frontItem := l.Front()
+		l.MoveToFront(frontItem) // [10, 20, 30]
+
+		// Порядок не должен измениться
+		require.Equal(t, 10, l.Front().Value)
+		require.Equal(t, 30, l.Back().Value)
+		require.Equal(t, 3, l.Len())
+	})
+
+	t.Run("push and remove multiple", func(t *testing.T) {
+		l := NewList[int]()
+
+		item1 := l.PushFront(1)
+		item2 := l.PushFront(2)
+		item3 := l.PushBack(3)
+		item4 := l.PushBack(4) // [1, 2, 3, 4]
+
+		require.Equal(t, 4, l.Len())
+
+		// Удаляем элементы в разном порядке
+		l.Remove(item2) // [1, 3, 4]
+		require.Equal(t, 3, l.Len())
+		require.Equal(t, 1, l.Front().Value)
+
+		l.Remove(item4) // [1, 3]
+		require.Equal(t, 2, l.Len())
+		require.Equal(t, 3, l.Back().Value)
+
+		l.Remove(item1) // [3]
+		require.Equal(t, 1, l.Len())
+		require.Equal(t, 3, l.Front().Value)
+		require.Equal(t, 3, l.Back().Value)
+
+		l.Remove(item3) // []
+		require.Equal(t, 0, l.Len())
+		require.Nil(t, l.Front())
+		require.Nil(t, l.Back())
+	})
+}
+
+func TestListContainerAPI(t *testing.T) {
+	t.Run("insert before and after", func(t *testing.T) {
+		l := NewList[int]()
+		mid := l.PushBack(20)
+
+		first := l.InsertBefore(10, mid)
+		last := l.InsertAfter(30, mid)
+		l.InsertAfter(15, first)
+		l.InsertBefore(25, last) // [10, 15, 20, 25, 30]
+
+		require.Equal(t, []int{10, 15, 20, 25, 30}, slices.Collect(l.All()))
+		require.Equal(t, []int{30, 25, 20, 15, 10}, slices.Collect(l.Backward()))
+		require.Equal(t, 5, l.Len())
+		require.Same(t, first, l.Front())
+		require.Same(t, last, l.Back())
+	})
+
+	t.Run("move to back", func(t *testing.T) {
+		l := NewList[int]()
+		first := l.PushBack(1)
+		l.PushBack(2)
+		last := l.PushBack(3)
+
+		l.MoveToBack(last) // уже в конце
+		l.MoveToBack(first)
+		require.Equal(t, []int{2, 3, 1}, slices.Collect(l.All()))
+		require.Same(t, first, l.Back())
+		require.Nil(t, first.Next)
+	})
+
+	t.Run("push list", func(t *testing.T) {
+		l := NewList[int]()
+		l.PushBack(3)
+		other := NewList[int]()
+		other.PushBack(1)
+		other.PushBack(2)
+
+		l.PushFrontList(other)
+		l.PushBackList(other)
+		require.Equal(t, []int{1, 2, 3, 1, 2}, slices.Collect(l.All()))
+		require.Equal(t, 2, other.Len())
+
+		// Добавление списка к самому себе
+		l.PushBackList(l)
+		require.Equal(t, []int{1, 2, 3, 1, 2, 1, 2, 3, 1, 2}, slices.Collect(l.All()))
+		l.PushFrontList(NewList[int]())
+		require.Equal(t, 10, l.Len())
+	})
+
+	t.Run("foreign items are ignored", func(t *testing.T) {
+		l := NewList[int]()
+		l.PushBack(1)
+		l.PushBack(2)
+		other := NewList[int]()
+		foreign := other.PushBack(10)
+
+		l.Remove(foreign)
+		l.MoveToFront(foreign)
+		l.MoveToBack(foreign)
+		require.Nil(t, l.InsertBefore(5, foreign))
+		require.Nil(t, l.InsertAfter(5, foreign))
+		require.Nil(t, l.InsertAfter(5, nil))
+		require.Equal(t, []int{1, 2}, slices.Collect(l.All()))
+		require.Equal(t, []int{10}, slices.Collect(other.All()))
+
+		// Повторное удаление не портит длину
+		removed := l.Front()
+		l.Remove(removed)
+		l.Remove(removed)
+		l.MoveToFront(removed)
+		require.Equal(t, 1, l.Len())
+		require.Equal(t, []int{2}, slices.Collect(l.All()))
+	})
+
+	t.Run("iteration", func(t *testing.T) {
+		l := NewList[int]()
+		for i := 1; i <= 6; i++ {
+			l.PushBack(i)
+		}
+
+		// Удаление текущего элемента во время обхода
+		for item := range l.Items() {
+			if item.Value%2 == 0 {
+				l.Remove(item)
+			}
+		}
+		require.Equal(t, []int{1, 3, 5}, slices.Collect(l.All()))
+
+		// Досрочный выход из обхода
+		var got []int
+		for v := range l.Backward() {
+			if v < 3 {
+				break
+			}
+			got = append(got, v)
+		}
+		require.Equal(t, []int{5, 3}, got)
+
+		for range NewList[int]().All() {
+			require.Fail(t, "empty list has no values")
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package lru
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// loadCall - выполняющаяся загрузка значения, которую ждут один или несколько вызовов GetOrLoad.
+type loadCall[V any] struct {
+	done    chan struct{}      // закрывается по завершении загрузки
+	value   V                  // результат загрузки, читается после закрытия done
+	err     error              // ошибка загрузки, читается после закрытия done
+	waiters int                // количество ожидающих вызовов GetOrLoad
+	ctx     context.Context    // контекст загрузки
+	cancel  context.CancelFunc // отменяет загрузку, когда ждать её некому
+}
+
+// PanicError - паника в функции загрузки GetOrLoad, которую ожидающие вызовы получают как ошибку.
+type PanicError struct {
+	Value any    // значение, переданное в panic
+	Stack []byte // стек горутины загрузки в момент паники
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("load panicked: %v", e.Value)
+}
+
+// Unwrap возвращает значение паники, если это ошибка.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// loadFailure - ошибка загрузки, запомненная до expiresAt.
+type loadFailure struct {
+	err       error
+	expiresAt time.Time
+}
+
+// expired проверяет, истёк ли срок хранения ошибки к моменту now.
+func (f loadFailure) expired(now time.Time) bool {
+	return !now.Before(f.expiresAt)
+}
+
+// GetOrLoad возвращает значение из кэша, а при его отсутствии загружает функцией load
+// и сохраняет в кэш. Одновременные вызовы для одного ключа ждут одну загрузку.
+//
+// Загрузка выполняется в отдельной горутине с собственным контекстом, который наследует
+// значения ctx вызова, начавшего загрузку, но не его отмену: отмена ctx прерывает ожидание
+// только этого вызова (он возвращает ctx.Err()), а контекст загрузки отменяется, когда её
+// перестают ждать все вызовы. Ошибка загрузки не сохраняется в кэш,
+// но запоминается на срок WithNegativeTTL, если он задан. Паника в load возвращается
+// ожидающим вызовам как *PanicError. Если во время загрузки ключ изменили Set, Delete
+// или Clear, её результат получают только ожидающие вызовы, а в кэш он не сохраняется.
+func (c *cache[K, V]) GetOrLoad(ctx context.Context, key K, load func(ctx context.Context) (V, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	var zero V
+	c.mu.Lock()
+	now := c.clock.Now()
+
+	// Значение могли загрузить, пока мьютекс был свободен
+	if e, ok := c.items[key]; ok && !e.expired(now) {
+		value := e.value
+		c.mu.Unlock()
+		return value, nil
+	}
+
+	if failure, ok := c.failures[key]; ok {
+		if !failure.expired(now) {
+			c.mu.Unlock()
+			return zero, failure.err
+		}
+		delete(c.failures, key)
+	}
+
+	call, ok := c.loads[key]
+	if !ok {
+		call = &loadCall[V]{done: make(chan struct{})}
+		call.ctx, call.cancel = context.WithCancel(context.WithoutCancel(ctx))
+		c.loads[key] = call
+		go c.load(key, call, load)
+	}
+	call.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Результат больше никому не нужен: отменяем загрузку,
+			// а следующий вызов GetOrLoad начнёт новую
+			call.cancel()
+			if c.loads[key] == call {
+				delete(c.loads, key)
+			}
+		}
+		c.mu.Unlock()
+		return zero, ctx.Err()
+	}
+}
+
+// load выполняет загрузку и сохраняет её результат, если загрузка не устарела.
+func (c *cache[K, V]) load(key K, call *loadCall[V], load func(ctx context.Context) (V, error)) {
+	value, err := callLoad(call.ctx, load)
+
+	c.mu.Lock()
+	current := c.loads[key] == call
+	if current {
+		delete(c.loads, key)
+	}
+
+	switch {
+	case !current:
+		// Set, Delete или Clear во время загрузки забыли её: результат устарел и не сохраняется,
+		// но ожидающие вызовы всё равно его получают
+	case err == nil:
+		// Элемент дороже ёмкости кэша просто не сохраняется, ожидающие всё равно получают значение
+		_, _ = c.set(key, value, 0)
+	case c.negativeTTL > 0 && call.ctx.Err() == nil:
+		// Ошибку отменённой загрузки не запоминаем: её вызвала отмена, а не источник данных
+		c.failures[key] = loadFailure{err: err, expiresAt: c.clock.Now().Add(c.negativeTTL)}
+	}
+	call.cancel()
+	c.unlock()
+
+	call.value, call.err = value, err
+	close(call.done)
+}
+
+// callLoad вызывает load и превращает её панику в *PanicError: загрузка идёт в отдельной
+// горутине, и без этого паника завершила бы весь процесс, а ожидающие вызовы зависли бы.
+func callLoad[V any](ctx context.Context, load func(ctx context.Context) (V, error)) (value V, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero V
+			value, err = zero, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return load(ctx)
+}
@@ -0,0 +1,237 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestCacheGetOrLoad(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errLoad := errors.New("load failed")
+	ctx := context.Background()
+
+	t.Run("loads once and caches", func(t *testing.T) {
+		c := NewCache[string, int](10)
+		var calls int32
+		load := func(context.Context) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return 42, nil
+		}
+
+		for i := 0; i < 3; i++ {
+			val, err := c.GetOrLoad(ctx, "aaa", load)
+			require.NoError(t, err)
+			require.Equal(t, 42, val)
+		}
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 42, val)
+	})
+
+	t.Run("concurrent calls share one load", func(t *testing.T) {
+		c := NewCache[string, int](10)
+		var calls int32
+		release := make(chan struct{})
+		load := func(context.Context) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return 7, nil
+		}
+
+		const workers = 50
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				val, err := c.GetOrLoad(ctx, "aaa", load)
+				require.NoError(t, err)
+				require.Equal(t, 7, val)
+			}()
+		}
+
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("load context keeps caller values", func(t *testing.T) {
+		type ctxKey struct{}
+		c := NewCache[string, string](10)
+		callerCtx, cancel := context.WithCancel(context.WithValue(ctx, ctxKey{}, "trace-1"))
+		defer cancel()
+
+		val, err := c.GetOrLoad(callerCtx, "aaa", func(loadCtx context.Context) (string, error) {
+			value, _ := loadCtx.Value(ctxKey{}).(string)
+			return value, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "trace-1", val)
+	})
+
+	t.Run("errors are not cached by default", func(t *testing.T) {
+		c := NewCache[string, int](10)
+		var calls int
+		load := func(context.Context) (int, error) {
+			calls++
+			return 0, errLoad
+		}
+
+		_, err := c.GetOrLoad(ctx, "aaa", load)
+		require.ErrorIs(t, err, errLoad)
+		_, err = c.GetOrLoad(ctx, "aaa", load)
+		require.ErrorIs(t, err, errLoad)
+		require.Equal(t, 2, calls)
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("load panic is returned as error", func(t *testing.T) {
+		c := NewCache[string, int](10)
+		_, err := c.GetOrLoad(ctx, "aaa", func(context.Context) (int, error) {
+			panic(errLoad)
+		})
+
+		var panicErr *PanicError
+		require.ErrorAs(t, err, &panicErr)
+		require.Equal(t, errLoad, panicErr.Value)
+		require.NotEmpty(t, panicErr.Stack)
+		require.ErrorIs(t, err, errLoad)
+		require.Equal(t, 0, c.Len())
+
+		// После паники загрузка не зависает и следующий вызов загружает значение заново
+		val, err := c.GetOrLoad(ctx, "aaa", func(context.Context) (int, error) {
+			return 42, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 42, val)
+	})
+
+	t.Run("negative caching", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock[string, int](clock), WithNegativeTTL[string, int](time.Second))
+		var calls int
+		load := func(context.Context) (int, error) {
+			calls++
+			return 0, errLoad
+		}
+
+		_, err := c.GetOrLoad(ctx, "aaa", load)
+		require.ErrorIs(t, err, errLoad)
+		_, err = c.GetOrLoad(ctx, "aaa", load)
+		require.ErrorIs(t, err, errLoad)
+		require.Equal(t, 1, calls)
+
+		clock.Advance(time.Second)
+		_, err = c.GetOrLoad(ctx, "aaa", load)
+		require.ErrorIs(t, err, errLoad)
+		require.Equal(t, 2, calls)
+
+		// Set заменяет запомненную ошибку
+		c.Set("aaa", 1)
+		c.Delete("aaa")
+		val, err := c.GetOrLoad(ctx, "aaa", func(context.Context) (int, error) { return 5, nil })
+		require.NoError(t, err)
+		require.Equal(t, 5, val)
+	})
+
+	t.Run("cancelled waiter", func(t *testing.T) {
+		c := NewCache[string, int](10)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		load := func(context.Context) (int, error) {
+			close(started)
+			<-release
+			return 3, nil
+		}
+
+		// Второй вызов ждёт дольше и получает значение, хотя первый отменён
+		result := make(chan int)
+		go func() {
+			val, err := c.GetOrLoad(ctx, "aaa", load)
+			require.NoError(t, err)
+			result <- val
+		}()
+		<-started
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := c.GetOrLoad(cancelCtx, "aaa", load)
+		require.ErrorIs(t, err, context.Canceled)
+
+		close(release)
+		require.Equal(t, 3, <-result)
+	})
+
+	t.Run("load is cancelled when nobody waits", func(t *testing.T) {
+		c := NewCache(10, WithNegativeTTL[string, int](time.Hour))
+		loadCancelled := make(chan struct{})
+		load := func(loadCtx context.Context) (int, error) {
+			<-loadCtx.Done()
+			close(loadCancelled)
+			return 0, loadCtx.Err()
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := c.GetOrLoad(waitCtx, "aaa", load)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		<-loadCancelled
+
+		// Ошибка отменённой загрузки не запоминается, следующий вызов загружает заново
+		require.Eventually(t, func() bool {
+			val, err := c.GetOrLoad(ctx, "aaa", func(context.Context) (int, error) { return 9, nil })
+			return err == nil && val == 9
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("set or delete during load wins", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			change func(c Cache[string, int])
+			want   int
+			ok     bool
+		}{
+			{name: "set", change: func(c Cache[string, int]) { c.Set("aaa", 2) }, want: 2, ok: true},
+			{name: "delete", change: func(c Cache[string, int]) { c.Delete("aaa") }},
+			{name: "clear", change: func(c Cache[string, int]) { c.Clear() }},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				c := NewCache[string, int](10)
+				started, release := make(chan struct{}), make(chan struct{})
+				result := make(chan int)
+				go func() {
+					val, _ := c.GetOrLoad(ctx, "aaa", func(context.Context) (int, error) {
+						close(started)
+						<-release
+						return 1, nil
+					})
+					result <- val
+				}()
+
+				<-started
+				tc.change(c)
+				close(release)
+
+				// Ожидающий вызов получает загруженное значение, но в кэш оно не попадает
+				require.Equal(t, 1, <-result)
+				val, ok := c.Get("aaa")
+				require.Equal(t, tc.ok, ok)
+				require.Equal(t, tc.want, val)
+			})
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package lru
+
+import "time"
+
+// Option настраивает кэш при создании.
+type Option[K comparable, V any] func(*cache[K, V])
+
+// WithClock задаёт источник времени для проверки срока жизни элементов.
+// По умолчанию используется системное время.
+func WithClock[K comparable, V any](clock Clock) Option[K, V] {
+	return func(c *cache[K, V]) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// WithJanitor запускает фоновую горутину, которая раз в interval удаляет элементы
+// с истёкшим сроком жизни. Без неё такие элементы удаляются только при обращении к ним
+// или при вытеснении. Горутина останавливается методом Close.
+func WithJanitor[K comparable, V any](interval time.Duration) Option[K, V] {
+	return func(c *cache[K, V]) {
+		c.janitorInterval = interval
+	}
+}
+
+// WithOnEvict задаёт функцию, вызываемую для каждого удалённого из кэша элемента,
+// например для освобождения связанных с ним ресурсов.
+// Функция вызывается после снятия блокировки кэша, поэтому может обращаться к кэшу.
+func WithOnEvict[K comparable, V any](onEvict func(key K, value V, reason EvictReason)) Option[K, V] {
+	return func(c *cache[K, V]) {
+		c.onEvict = onEvict
+	}
+}
+
+// WithPolicy задаёт политику вытеснения. По умолчанию используется PolicyLRU.
+func WithPolicy[K comparable, V any](policy Policy) Option[K, V] {
+	return func(c *cache[K, V]) {
+		c.policyKind = policy
+	}
+}
+
+// WithNegativeTTL включает запоминание ошибок загрузки в GetOrLoad на срок ttl:
+// пока срок не истёк, GetOrLoad возвращает ту же ошибку, не вызывая загрузку повторно.
+func WithNegativeTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *cache[K, V]) {
+		c.negativeTTL = ttl
+	}
+}
+
+// WithCodec задаёт формат, в котором Save и Load записывают и читают содержимое кэша.
+// По умолчанию используется GobCodec.
+func WithCodec[K comparable, V any](codec Codec) Option[K, V] {
+	return func(c *cache[K, V]) {
+		if codec != nil {
+			c.codec = codec
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package lru
+
+import "fmt"
+
+// Policy - политика вытеснения элементов из кэша.
+type Policy int
+
+const (
+	// PolicyLRU вытесняет самый давно использованный элемент.
+	PolicyLRU Policy = iota
+	// PolicyLFU вытесняет элемент с наименьшим числом обращений,
+	// среди равных - самый давно использованный.
+	PolicyLFU
+	// Policy2Q держит новые элементы в отдельной очереди и переносит в основную
+	// только те, к которым обратились повторно, поэтому однократное чтение
+	// большого числа ключей не вытесняет часто используемые элементы.
+	Policy2Q
+	// PolicyARC (Adaptive Replacement Cache) делит кэш между недавно и часто
+	// используемыми элементами и подстраивает границу по истории вытесненных ключей.
+	PolicyARC
+)
+
+func (p Policy) String() string {
+	switch p {
+	case PolicyLRU:
+		return "LRU"
+	case PolicyLFU:
+		return "LFU"
+	case Policy2Q:
+		return "2Q"
+	case PolicyARC:
+		return "ARC"
+	default:
+		return fmt.Sprintf("Policy(%d)", int(p))
+	}
+}
+
+// policy определяет порядок элементов кэша и выбирает вытесняемый.
+// Словарь, сроки жизни и учёт стоимости остаются в cache; все методы вызываются под его мьютексом.
+type policy[K comparable, V any] interface {
+	// add добавляет новый элемент.
+	add(e *entry[K, V])
+	// access отмечает обращение к элементу (Get или Set существующего ключа).
+	access(e *entry[K, V])
+	// remove убирает элемент без вытеснения (Delete, истечение срока жизни).
+	remove(e *entry[K, V])
+	// evict выбирает, убирает и возвращает вытесняемый элемент, отличный от keep.
+	// Возвращает nil, если других элементов нет.
+	evict(keep *entry[K, V]) *entry[K, V]
+	// each обходит элементы, начиная с того, который будет вытеснен последним.
+	each(fn func(e *entry[K, V]))
+	// resize сообщает политике новую ёмкость кэша.
+	resize(capacity int)
+	// clear убирает все элементы и забывает историю обращений.
+	clear()
+}
+
+// newPolicy создаёт политику вытеснения для кэша ёмкостью capacity.
+// Неизвестная политика заменяется на LRU.
+func newPolicy[K comparable, V any](kind Policy, capacity int) policy[K, V] {
+	switch kind {
+	case PolicyLRU:
+		return newLRUPolicy[K, V]()
+	case PolicyLFU:
+		return newLFUPolicy[K, V]()
+	case Policy2Q:
+		return newTwoQueuePolicy[K, V](capacity)
+	case PolicyARC:
+		return newARCPolicy[K, V](capacity)
+	default:
+		return newLRUPolicy[K, V]()
+	}
+}
+
+// entryList - список элементов кэша (самые свежие в начале) с суммарной стоимостью.
+type entryList[K comparable, V any] struct {
+	items List[*entry[K, V]]
+	size  int // суммарная стоимость элементов списка
+}
+
+func newEntryList[K comparable, V any]() *entryList[K, V] {
+	return &entryList[K, V]{items: NewList[*entry[K, V]]()}
+}
+
+// pushFront добавляет элемент в начало списка.
+func (l *entryList[K, V]) pushFront(e *entry[K, V]) {
+	e.node = l.items.PushFront(e)
+	e.list = l
+	l.size += e.cost
+}
+
+// remove убирает элемент из списка.
+func (l *entryList[K, V]) remove(e *entry[K, V]) {
+	l.items.Remove(e.node)
+	l.size -= e.cost
+	e.node = nil
+	e.list = nil
+}
+
+// moveToFront перемещает элемент в начало списка.
+func (l *entryList[K, V]) moveToFront(e *entry[K, V]) {
+	l.items.MoveToFront(e.node)
+}
+
+// setCost меняет стоимость элемента списка, сохраняя суммарную стоимость.
+func (l *entryList[K, V]) setCost(e *entry[K, V], cost int) {
+	l.size += cost - e.cost
+	e.cost = cost
+}
+
+// backExcept возвращает последний элемент списка, пропуская keep, или nil.
+// keep - не больше одного элемента, поэтому достаточно проверить два последних.
+func (l *entryList[K, V]) backExcept(keep *entry[K, V]) *entry[K, V] {
+	item := l.items.Back()
+	if item != nil && item.Value == keep {
+		item = item.Prev
+	}
+	if item == nil {
+		return nil
+	}
+	return item.Value
+}
+
+// each обходит элементы от начала списка к концу.
+func (l *entryList[K, V]) each(fn func(e *entry[K, V])) {
+	for item := l.items.Front(); item != nil; item = item.Next {
+		fn(item.Value)
+	}
+}
+
+// ghost - ключ вытесненного элемента, который помнят 2Q и ARC.
+type ghost[K comparable] struct {
+	key  K
+	cost int
+}
+
+// ghostList - список ключей вытесненных элементов (самые свежие в начале).
+type ghostList[K comparable] struct {
+	keys  List[ghost[K]]
+	items map[K]*ListItem[ghost[K]]
+	size  int // суммарная стоимость вытесненных элементов
+}
+
+func newGhostList[K comparable]() *ghostList[K] {
+	return &ghostList[K]{keys: NewList[ghost[K]](), items: make(map[K]*ListItem[ghost[K]])}
+}
+
+// pushFront запоминает ключ вытесненного элемента.
+// Нулевая стоимость учитывается как 1, иначе такие ключи не ограничивались бы размером истории.
+func (l *ghostList[K]) pushFront(key K, cost int) {
+	cost = max(cost, 1)
+	l.items[key] = l.keys.PushFront(ghost[K]{key: key, cost: cost})
+	l.size += cost
+}
+
+// take забывает ключ и возвращает стоимость элемента, если ключ был в списке.
+func (l *ghostList[K]) take(key K) (int, bool) {
+	item, ok := l.items[key]
+	if !ok {
+		return 0, false
+	}
+	l.keys.Remove(item)
+	delete(l.items, key)
+	l.size -= item.Value.cost
+	return item.Value.cost, true
+}
+
+// removeBack забывает самый давний ключ.
+func (l *ghostList[K]) removeBack() {
+	if item := l.keys.Back(); item != nil {
+		l.take(item.Value.key)
+	}
+}
+
+// len возвращает количество ключей в списке.
+func (l *ghostList[K]) len() int {
+	return l.keys.Len()
+}
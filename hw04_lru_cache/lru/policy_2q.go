@@ -0,0 +1,99 @@
+package lru
+
+// twoQueuePolicy - политика 2Q (Johnson, Shasha). Новые элементы попадают в очередь recent,
+// откуда вытесняются первыми; их ключи запоминаются в ghosts. Повторное добавление
+// такого ключа помещает элемент в основную LRU-очередь frequent.
+// Размеры очередей измеряются в стоимости элементов.
+type twoQueuePolicy[K comparable, V any] struct {
+	recent    *entryList[K, V] // A1in: элементы, к которым обращались один раз (FIFO)
+	frequent  *entryList[K, V] // Am: элементы с повторными обращениями (LRU)
+	ghosts    *ghostList[K]    // A1out: ключи вытесненных из recent элементов
+	recentCap int              // Kin: размер recent, после которого вытесняется из него
+	ghostsCap int              // Kout: размер ghosts
+}
+
+// Доли ёмкости кэша для очереди новых элементов и для истории вытесненных ключей,
+// рекомендованные авторами 2Q.
+const (
+	twoQueueRecentRatio = 0.25
+	twoQueueGhostsRatio = 0.5
+)
+
+func newTwoQueuePolicy[K comparable, V any](capacity int) *twoQueuePolicy[K, V] {
+	p := &twoQueuePolicy[K, V]{}
+	p.clear()
+	p.resize(capacity)
+	return p
+}
+
+func (p *twoQueuePolicy[K, V]) add(e *entry[K, V]) {
+	if _, ok := p.ghosts.take(e.key); ok {
+		// Ключ недавно вытеснялся - к нему обращаются повторно
+		p.frequent.pushFront(e)
+		return
+	}
+	p.recent.pushFront(e)
+}
+
+func (p *twoQueuePolicy[K, V]) access(e *entry[K, V]) {
+	// Обращения к элементу из recent не меняют его положения: короткая серия
+	// обращений сразу после добавления не делает элемент часто используемым
+	if e.list == p.frequent {
+		p.frequent.moveToFront(e)
+	}
+}
+
+func (p *twoQueuePolicy[K, V]) remove(e *entry[K, V]) {
+	e.list.remove(e)
+}
+
+func (p *twoQueuePolicy[K, V]) evict(keep *entry[K, V]) *entry[K, V] {
+	if p.recent.size > p.recentCap || p.frequent.items.Len() == 0 {
+		if victim := p.recent.backExcept(keep); victim != nil {
+			p.recent.remove(victim)
+			p.remember(victim)
+			return victim
+		}
+	}
+
+	if victim := p.frequent.backExcept(keep); victim != nil {
+		p.frequent.remove(victim)
+		return victim
+	}
+	if victim := p.recent.backExcept(keep); victim != nil {
+		p.recent.remove(victim)
+		p.remember(victim)
+		return victim
+	}
+	return nil
+}
+
+func (p *twoQueuePolicy[K, V]) each(fn func(e *entry[K, V])) {
+	p.frequent.each(fn)
+	p.recent.each(fn)
+}
+
+func (p *twoQueuePolicy[K, V]) resize(capacity int) {
+	p.recentCap = int(float64(capacity) * twoQueueRecentRatio)
+	p.ghostsCap = int(float64(capacity) * twoQueueGhostsRatio)
+	p.trimGhosts()
+}
+
+func (p *twoQueuePolicy[K, V]) clear() {
+	p.recent = newEntryList[K, V]()
+	p.frequent = newEntryList[K, V]()
+	p.ghosts = newGhostList[K]()
+}
+
+// remember запоминает ключ вытесненного из recent элемента.
+func (p *twoQueuePolicy[K, V]) remember(e *entry[K, V]) {
+	p.ghosts.pushFront(e.key, e.cost)
+	p.trimGhosts()
+}
+
+// trimGhosts забывает самые давние ключи, пока история больше ghostsCap.
+func (p *twoQueuePolicy[K, V]) trimGhosts() {
+	for p.ghosts.size > p.ghostsCap && p.ghosts.len() > 0 {
+		p.ghosts.removeBack()
+	}
+}
@@ -0,0 +1,138 @@
+package lru
+
+// arcPolicy - политика ARC (Megiddo, Modha). Элементы, к которым обращались один раз,
+// лежат в recent (T1), повторно - в frequent (T2). Ключи вытесненных из них элементов
+// запоминаются в recentGhosts (B1) и frequentGhosts (B2). Повторное добавление ключа
+// из B1 увеличивает целевой размер T1, из B2 - уменьшает, так что кэш сам подстраивается
+// под соотношение разовых и повторных обращений.
+// Размеры списков и target измеряются в стоимости элементов.
+type arcPolicy[K comparable, V any] struct {
+	recent         *entryList[K, V] // T1
+	frequent       *entryList[K, V] // T2
+	recentGhosts   *ghostList[K]    // B1
+	frequentGhosts *ghostList[K]    // B2
+	capacity       int              // c: ёмкость кэша
+	target         int              // p: целевой размер T1
+	frequentHit    bool             // последний добавленный ключ был найден в B2
+}
+
+func newARCPolicy[K comparable, V any](capacity int) *arcPolicy[K, V] {
+	p := &arcPolicy[K, V]{}
+	p.clear()
+	p.resize(capacity)
+	return p
+}
+
+func (p *arcPolicy[K, V]) add(e *entry[K, V]) {
+	p.frequentHit = false
+
+	if cost, ok := p.recentGhosts.take(e.key); ok {
+		// Элемент вытеснен из T1 слишком рано - увеличиваем T1
+		p.target += arcStep(cost, p.recentGhosts.size+cost, p.frequentGhosts.size)
+		if p.target > p.capacity {
+			p.target = p.capacity
+		}
+		p.frequent.pushFront(e)
+		return
+	}
+
+	if cost, ok := p.frequentGhosts.take(e.key); ok {
+		// Элемент вытеснен из T2 слишком рано - уменьшаем T1
+		p.target -= arcStep(cost, p.frequentGhosts.size+cost, p.recentGhosts.size)
+		if p.target < 0 {
+			p.target = 0
+		}
+		p.frequentHit = true
+		p.frequent.pushFront(e)
+		return
+	}
+
+	p.recent.pushFront(e)
+	p.trimGhosts()
+}
+
+func (p *arcPolicy[K, V]) access(e *entry[K, V]) {
+	if e.list == p.recent {
+		p.recent.remove(e)
+		p.frequent.pushFront(e)
+		return
+	}
+	p.frequent.moveToFront(e)
+}
+
+func (p *arcPolicy[K, V]) remove(e *entry[K, V]) {
+	e.list.remove(e)
+}
+
+func (p *arcPolicy[K, V]) evict(keep *entry[K, V]) *entry[K, V] {
+	fromRecent := p.recent.size > 0 &&
+		(p.recent.size > p.target || (p.frequentHit && p.recent.size == p.target))
+
+	lists := [2]*entryList[K, V]{p.frequent, p.recent}
+	if fromRecent {
+		lists[0], lists[1] = lists[1], lists[0]
+	}
+
+	for _, list := range lists {
+		victim := list.backExcept(keep)
+		if victim == nil {
+			continue
+		}
+
+		list.remove(victim)
+		if list == p.recent {
+			p.recentGhosts.pushFront(victim.key, victim.cost)
+		} else {
+			p.frequentGhosts.pushFront(victim.key, victim.cost)
+		}
+		p.trimGhosts()
+		return victim
+	}
+	return nil
+}
+
+func (p *arcPolicy[K, V]) each(fn func(e *entry[K, V])) {
+	p.frequent.each(fn)
+	p.recent.each(fn)
+}
+
+func (p *arcPolicy[K, V]) resize(capacity int) {
+	p.capacity = capacity
+	if p.target > capacity {
+		p.target = capacity
+	}
+	p.trimGhosts()
+}
+
+func (p *arcPolicy[K, V]) clear() {
+	p.recent = newEntryList[K, V]()
+	p.frequent = newEntryList[K, V]()
+	p.recentGhosts = newGhostList[K]()
+	p.frequentGhosts = newGhostList[K]()
+	p.target = 0
+	p.frequentHit = false
+}
+
+// arcStep возвращает шаг изменения target при повторном добавлении ключа стоимостью cost
+// из истории размером hitSize, когда размер другой истории - otherSize.
+// Чем меньше история, в которую попал ключ, тем сильнее сдвигается граница.
+func arcStep(cost, hitSize, otherSize int) int {
+	if hitSize > 0 && otherSize > hitSize {
+		return cost * (otherSize / hitSize)
+	}
+	return cost
+}
+
+// trimGhosts ограничивает историю: T1 вместе с B1 и весь кэш вместе с историей
+// занимают не больше c и 2c соответственно.
+func (p *arcPolicy[K, V]) trimGhosts() {
+	for p.recent.size+p.recentGhosts.size > p.capacity && p.recentGhosts.len() > 0 {
+		p.recentGhosts.removeBack()
+	}
+
+	total := p.recent.size + p.frequent.size + p.recentGhosts.size + p.frequentGhosts.size
+	for total > 2*p.capacity && p.frequentGhosts.len() > 0 {
+		p.frequentGhosts.removeBack()
+		total = p.recent.size + p.frequent.size + p.recentGhosts.size + p.frequentGhosts.size
+	}
+}
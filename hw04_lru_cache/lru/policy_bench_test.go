@@ -0,0 +1,69 @@
+package lru
+
+import (
+	"bytes"
+	"flag"
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+)
+
+var (
+	traceFile     = flag.String("trace", "", "файл трассы для BenchmarkPolicies: по ключу в строке")
+	traceCapacity = flag.Int("trace-capacity", 500, "ёмкость кэша для BenchmarkPolicies")
+)
+
+var policies = []Policy{PolicyLRU, PolicyLFU, Policy2Q, PolicyARC}
+
+// scanTrace генерирует трассу, в которой обращения к небольшому набору горячих ключей
+// с распределением Ципфа перемежаются однократным чтением длинных серий новых ключей.
+func scanTrace(requests, hot, scan int) []byte {
+	rnd := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rnd, 1.1, 1, uint64(hot-1))
+
+	var buf bytes.Buffer
+	scanned := 0
+	for i := 0; i < requests; i++ {
+		if i%(scan*4) < scan {
+			buf.WriteString("scan")
+			buf.WriteString(strconv.Itoa(scanned))
+			scanned++
+		} else {
+			buf.WriteString("hot")
+			buf.WriteString(strconv.FormatUint(zipf.Uint64(), 10))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// BenchmarkPolicies воспроизводит трассу на кэше с каждой политикой и сообщает долю попаданий.
+// Свою трассу можно передать флагом: go test -bench Policies -trace keys.txt -trace-capacity 1000.
+func BenchmarkPolicies(b *testing.B) {
+	trace := scanTrace(200_000, 1_000, 2_000)
+	if *traceFile != "" {
+		data, err := os.ReadFile(*traceFile)
+		if err != nil {
+			b.Fatal(err)
+		}
+		trace = data
+	}
+
+	for _, policy := range policies {
+		policy := policy
+		b.Run(policy.String(), func(b *testing.B) {
+			var result TraceResult
+			for i := 0; i < b.N; i++ {
+				c := NewCache(*traceCapacity, WithPolicy[string, struct{}](policy))
+
+				var err error
+				result, err = ReplayTrace(c, bytes.NewReader(trace))
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(result.HitRatio(), "hit-ratio")
+		})
+	}
+}
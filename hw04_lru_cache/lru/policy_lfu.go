@@ -0,0 +1,111 @@
+package lru
+
+// lfuBucket - элементы с одинаковым числом обращений freq (самые свежие в начале).
+// Группы связаны в список по возрастанию freq.
+type lfuBucket[K comparable, V any] struct {
+	freq    int
+	entries *entryList[K, V]
+	prev    *lfuBucket[K, V]
+	next    *lfuBucket[K, V]
+}
+
+// lfuPolicy вытесняет элемент с наименьшим числом обращений, среди равных - самый давний.
+// Все операции выполняются за O(1): обращение переносит элемент в соседнюю группу.
+type lfuPolicy[K comparable, V any] struct {
+	head *lfuBucket[K, V] // группа с наименьшим числом обращений
+	tail *lfuBucket[K, V] // группа с наибольшим числом обращений
+}
+
+func newLFUPolicy[K comparable, V any]() *lfuPolicy[K, V] {
+	return &lfuPolicy[K, V]{}
+}
+
+func (p *lfuPolicy[K, V]) add(e *entry[K, V]) {
+	if p.head == nil || p.head.freq != 1 {
+		p.insertAfter(nil, 1)
+	}
+	p.put(p.head, e)
+}
+
+func (p *lfuPolicy[K, V]) access(e *entry[K, V]) {
+	bucket := e.bucket
+	next := bucket.next
+	if next == nil || next.freq != bucket.freq+1 {
+		next = p.insertAfter(bucket, bucket.freq+1)
+	}
+	p.remove(e)
+	p.put(next, e)
+}
+
+func (p *lfuPolicy[K, V]) remove(e *entry[K, V]) {
+	bucket := e.bucket
+	bucket.entries.remove(e)
+	e.bucket = nil
+	if bucket.entries.items.Len() == 0 {
+		p.unlink(bucket)
+	}
+}
+
+func (p *lfuPolicy[K, V]) evict(keep *entry[K, V]) *entry[K, V] {
+	// keep может оказаться единственным элементом самой первой группы,
+	// тогда жертва берётся из следующей
+	for bucket := p.head; bucket != nil; bucket = bucket.next {
+		if victim := bucket.entries.backExcept(keep); victim != nil {
+			p.remove(victim)
+			return victim
+		}
+	}
+	return nil
+}
+
+func (p *lfuPolicy[K, V]) each(fn func(e *entry[K, V])) {
+	for bucket := p.tail; bucket != nil; bucket = bucket.prev {
+		bucket.entries.each(fn)
+	}
+}
+
+func (p *lfuPolicy[K, V]) resize(int) {}
+
+func (p *lfuPolicy[K, V]) clear() {
+	p.head = nil
+	p.tail = nil
+}
+
+// put добавляет элемент в начало группы.
+func (p *lfuPolicy[K, V]) put(bucket *lfuBucket[K, V], e *entry[K, V]) {
+	bucket.entries.pushFront(e)
+	e.bucket = bucket
+}
+
+// insertAfter создаёт пустую группу с числом обращений freq после prev (nil - в начале).
+func (p *lfuPolicy[K, V]) insertAfter(prev *lfuBucket[K, V], freq int) *lfuBucket[K, V] {
+	bucket := &lfuBucket[K, V]{freq: freq, entries: newEntryList[K, V](), prev: prev}
+	if prev != nil {
+		bucket.next = prev.next
+		prev.next = bucket
+	} else {
+		bucket.next = p.head
+		p.head = bucket
+	}
+
+	if bucket.next != nil {
+		bucket.next.prev = bucket
+	} else {
+		p.tail = bucket
+	}
+	return bucket
+}
+
+// unlink удаляет пустую группу из списка групп.
+func (p *lfuPolicy[K, V]) unlink(bucket *lfuBucket[K, V]) {
+	if bucket.prev != nil {
+		bucket.prev.next = bucket.next
+	} else {
+		p.head = bucket.next
+	}
+	if bucket.next != nil {
+		bucket.next.prev = bucket.prev
+	} else {
+		p.tail = bucket.prev
+	}
+}
@@ -0,0 +1,41 @@
+package lru
+
+// lruPolicy держит элементы в одной очереди: обращение перемещает элемент
+// в начало, вытесняется последний.
+type lruPolicy[K comparable, V any] struct {
+	queue *entryList[K, V]
+}
+
+func newLRUPolicy[K comparable, V any]() *lruPolicy[K, V] {
+	return &lruPolicy[K, V]{queue: newEntryList[K, V]()}
+}
+
+func (p *lruPolicy[K, V]) add(e *entry[K, V]) {
+	p.queue.pushFront(e)
+}
+
+func (p *lruPolicy[K, V]) access(e *entry[K, V]) {
+	p.queue.moveToFront(e)
+}
+
+func (p *lruPolicy[K, V]) remove(e *entry[K, V]) {
+	p.queue.remove(e)
+}
+
+func (p *lruPolicy[K, V]) evict(keep *entry[K, V]) *entry[K, V] {
+	victim := p.queue.backExcept(keep)
+	if victim != nil {
+		p.queue.remove(victim)
+	}
+	return victim
+}
+
+func (p *lruPolicy[K, V]) each(fn func(e *entry[K, V])) {
+	p.queue.each(fn)
+}
+
+func (p *lruPolicy[K, V]) resize(int) {}
+
+func (p *lruPolicy[K, V]) clear() {
+	p.queue = newEntryList[K, V]()
+}
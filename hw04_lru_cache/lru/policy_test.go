@@ -0,0 +1,236 @@
+package lru
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// touch читает ключ из кэша и добавляет его при промахе.
+func touch(c Cache[string, int], key string) {
+	if _, ok := c.Get(key); !ok {
+		c.Set(key, 0)
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	for _, policy := range policies {
+		policy := policy
+		t.Run(policy.String(), func(t *testing.T) {
+			t.Run("simple", func(t *testing.T) {
+				c := NewCache(2, WithPolicy[string, int](policy))
+
+				require.False(t, c.Set("aaa", 100))
+				require.False(t, c.Set("bbb", 200))
+				require.True(t, c.Set("aaa", 300))
+
+				val, ok := c.Get("aaa")
+				require.True(t, ok)
+				require.Equal(t, 300, val)
+
+				c.Set("ccc", 400)
+				require.Equal(t, 2, c.Len())
+				require.Len(t, c.Keys(), 2)
+
+				require.True(t, c.Delete("ccc"))
+				require.Equal(t, 1, c.Len())
+			})
+
+			t.Run("ttl", func(t *testing.T) {
+				clock := newFakeClock()
+				c := NewCache(3, WithPolicy[string, int](policy), WithClock[string, int](clock))
+
+				c.SetWithTTL("aaa", 1, time.Second)
+				c.Set("bbb", 2)
+				clock.Advance(time.Second)
+
+				_, ok := c.Get("aaa")
+				require.False(t, ok)
+				require.Equal(t, []string{"bbb"}, c.Keys())
+			})
+
+			t.Run("cost", func(t *testing.T) {
+				c := NewCacheWithCost(10, func(key string, value int) int { return value }, WithPolicy[string, int](policy))
+
+				c.Set("aaa", 4)
+				c.Set("bbb", 4)
+				c.Set("ccc", 4)
+				require.LessOrEqual(t, c.Stats().Cost, 10)
+
+				_, err := c.TrySet("ddd", 11, 0)
+				require.ErrorIs(t, err, ErrItemTooLarge)
+
+				// Только что добавленный элемент не вытесняется, даже если занимает почти всю ёмкость
+				c.Set("eee", 10)
+				require.Equal(t, []string{"eee"}, c.Keys())
+			})
+
+			t.Run("clear and resize", func(t *testing.T) {
+				cleared := 0
+				c := NewCache(10, WithPolicy[string, int](policy), WithOnEvict(func(string, int, EvictReason) {
+					cleared++
+				}))
+				for i := 0; i < 10; i++ {
+					touch(c, strconv.Itoa(i))
+				}
+
+				c.Resize(4)
+				require.Equal(t, 4, c.Len())
+				require.Equal(t, 6, cleared)
+
+				c.Clear()
+				require.Equal(t, 0, c.Len())
+				require.Equal(t, 10, cleared)
+
+				touch(c, "a")
+				require.Equal(t, []string{"a"}, c.Keys())
+			})
+		})
+	}
+}
+
+func TestPoliciesScanResistance(t *testing.T) {
+	const capacity, hot = 100, 20
+
+	expected := map[Policy]int{PolicyLRU: 0, PolicyLFU: hot, Policy2Q: hot, PolicyARC: hot}
+	for _, policy := range policies {
+		policy := policy
+		t.Run(policy.String(), func(t *testing.T) {
+			c := NewCache(capacity, WithPolicy[string, int](policy))
+
+			// Горячие ключи используются постоянно вперемешку с разовыми
+			unique := 0
+			for round := 0; round < 50; round++ {
+				for i := 0; i < hot; i++ {
+					touch(c, "hot"+strconv.Itoa(i))
+					touch(c, "once"+strconv.Itoa(unique))
+					unique++
+				}
+			}
+
+			// Однократное чтение множества новых ключей
+			for i := 0; i < capacity*5; i++ {
+				touch(c, "scan"+strconv.Itoa(i))
+			}
+
+			survived := 0
+			for i := 0; i < hot; i++ {
+				if _, ok := c.Peek("hot" + strconv.Itoa(i)); ok {
+					survived++
+				}
+			}
+			require.Equal(t, expected[policy], survived)
+		})
+	}
+}
+
+func TestLFUPolicy(t *testing.T) {
+	c := NewCache(3, WithPolicy[string, int](PolicyLFU))
+
+	c.Set("aaa", 1)
+	c.Set("bbb", 2)
+	c.Set("ccc", 3)
+	c.Get("aaa")
+	c.Get("aaa")
+	c.Get("ccc")
+
+	// bbb используется реже всех
+	c.Set("ddd", 4)
+	require.Equal(t, []string{"aaa", "ccc", "ddd"}, c.Keys())
+
+	// Среди одинаково редких вытесняется самый давний
+	c.Set("eee", 5)
+	require.Equal(t, []string{"aaa", "ccc", "eee"}, c.Keys())
+}
+
+func TestARCPolicyAdapts(t *testing.T) {
+	c := NewCache(4, WithPolicy[string, int](PolicyARC)).(*cache[string, int])
+	arc := c.policy.(*arcPolicy[string, int])
+
+	// a и b используются повторно и попадают в T2, c вытесняется из T1 в историю B1
+	for _, key := range []string{"a", "b", "a", "b", "c", "d", "e"} {
+		touch(c, key)
+	}
+	require.Equal(t, 0, arc.target)
+	require.Equal(t, 1, arc.recentGhosts.len())
+	require.Equal(t, []string{"b", "a", "e", "d"}, c.Keys())
+
+	// Повторное добавление ключа, вытесненного из T1, увеличивает целевой размер T1
+	touch(c, "c")
+	require.Equal(t, 1, arc.target)
+	require.Same(t, arc.frequent, c.items["c"].list)
+}
+
+// TestPoliciesInvariants проверяет согласованность словаря, политики и учёта стоимости
+// на случайной последовательности операций.
+func TestPoliciesInvariants(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		keys     int // количество разных ключей
+		maxCost  int // стоимость элемента - от 0 до maxCost-1
+	}{
+		{name: "mixed costs", capacity: 50, keys: 100, maxCost: 10},
+		// Малая ёмкость и много элементов нулевой стоимости: в истории 2Q и ARC
+		// часто остаются только такие ключи.
+		{name: "zero costs", capacity: 4, keys: 10, maxCost: 3},
+	}
+
+	for _, policy := range policies {
+		for _, tc := range tests {
+			policy, tc := policy, tc
+			t.Run(policy.String()+"/"+tc.name, func(t *testing.T) {
+				rnd := rand.New(rand.NewSource(1))
+				clock := newFakeClock()
+				c := NewCacheWithCost(tc.capacity, func(key string, value int) int { return value },
+					WithPolicy[string, int](policy), WithClock[string, int](clock)).(*cache[string, int])
+
+				for i := 0; i < 20_000; i++ {
+					key := strconv.Itoa(rnd.Intn(tc.keys))
+					switch rnd.Intn(10) {
+					case 0:
+						c.Delete(key)
+					case 1:
+						c.SetWithTTL(key, rnd.Intn(tc.maxCost), time.Duration(rnd.Intn(3))*time.Second)
+					case 2:
+						clock.Advance(time.Second)
+					case 3:
+						c.removeExpired()
+					case 4:
+						if rnd.Intn(100) == 0 {
+							c.Resize(tc.capacity/2 + rnd.Intn(tc.capacity*3/2))
+						}
+					case 5, 6:
+						c.Set(key, rnd.Intn(tc.maxCost))
+					default:
+						c.Get(key)
+					}
+
+					seen, used := 0, 0
+					c.policy.each(func(e *entry[string, int]) {
+						require.Same(t, e, c.items[e.key])
+						seen++
+						used += e.cost
+					})
+					require.Equal(t, len(c.items), seen)
+					require.Equal(t, used, c.used)
+					require.LessOrEqual(t, c.used, c.capacity)
+				}
+			})
+		}
+	}
+}
+
+func TestReplayTrace(t *testing.T) {
+	c := NewCache[string, struct{}](2)
+
+	result, err := ReplayTrace(c, strings.NewReader("a\nb\na 10\n\nc\nb\na\n"))
+	require.NoError(t, err)
+	require.Equal(t, TraceResult{Hits: 1, Misses: 5}, result)
+	require.InDelta(t, 1.0/6, result.HitRatio(), 1e-9)
+	require.Zero(t, TraceResult{}.HitRatio())
+}
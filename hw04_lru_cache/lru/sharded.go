@@ -0,0 +1,158 @@
+package lru
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// shardedCache делит ключи между несколькими независимыми кэшами (шардами).
+// Каждый шард защищён своим мьютексом, поэтому горутины, работающие с ключами
+// из разных шардов, не ждут друг друга.
+type shardedCache[K comparable, V any] struct {
+	shards []*cache[K, V]
+	hash   func(K) uint32
+}
+
+// NewShardedCache создаёт кэш из shards шардов с общей ёмкостью не меньше capacity.
+// hash распределяет ключи по шардам и должен давать одинаковое значение для равных ключей.
+// Вытеснение происходит внутри шарда: из шарда удаляется выбранный его политикой элемент,
+// который не обязательно самый давний во всём кэше.
+// При shards < 1 используется один шард.
+// Опции применяются к каждому шарду.
+func NewShardedCache[K comparable, V any](
+	capacity, shards int, hash func(K) uint32, opts ...Option[K, V],
+) Cache[K, V] {
+	if shards < 1 {
+		shards = 1
+	}
+
+	// Ёмкость шарда округляем вверх, чтобы общая ёмкость была не меньше capacity
+	shardCapacity := (capacity + shards - 1) / shards
+
+	c := &shardedCache[K, V]{shards: make([]*cache[K, V], shards), hash: hash}
+	for i := range c.shards {
+		c.shards[i] = NewCache(shardCapacity, opts...).(*cache[K, V])
+	}
+	return c
+}
+
+// shard возвращает шард, в котором хранится ключ.
+func (c *shardedCache[K, V]) shard(key K) *cache[K, V] {
+	return c.shards[c.hash(key)%uint32(len(c.shards))]
+}
+
+// Set добавляет значение в кэш по ключу.
+// Возвращаемое значение - флаг, присутствовал ли элемент в кэше.
+func (c *shardedCache[K, V]) Set(key K, value V) bool {
+	return c.shard(key).Set(key, value)
+}
+
+// SetWithTTL добавляет значение в кэш по ключу со сроком жизни ttl.
+func (c *shardedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) bool {
+	return c.shard(key).SetWithTTL(key, value, ttl)
+}
+
+// TrySet добавляет значение в кэш по ключу и сообщает, если элемент не добавлен.
+func (c *shardedCache[K, V]) TrySet(key K, value V, ttl time.Duration) (bool, error) {
+	return c.shard(key).TrySet(key, value, ttl)
+}
+
+// Get получает значение из кэша по ключу.
+func (c *shardedCache[K, V]) Get(key K) (V, bool) {
+	return c.shard(key).Get(key)
+}
+
+// GetOrLoad возвращает значение из кэша, а при его отсутствии загружает функцией load.
+func (c *shardedCache[K, V]) GetOrLoad(
+	ctx context.Context, key K, load func(ctx context.Context) (V, error),
+) (V, error) {
+	return c.shard(key).GetOrLoad(ctx, key, load)
+}
+
+// Peek получает значение из кэша по ключу, не меняя его положения в очереди.
+func (c *shardedCache[K, V]) Peek(key K) (V, bool) {
+	return c.shard(key).Peek(key)
+}
+
+// Delete удаляет элемент из кэша по ключу.
+func (c *shardedCache[K, V]) Delete(key K) bool {
+	return c.shard(key).Delete(key)
+}
+
+// Keys возвращает ключи всех шардов. Ключи упорядочены от самого недавно
+// использованного к самому давнему только в пределах своего шарда.
+func (c *shardedCache[K, V]) Keys() []K {
+	var keys []K
+	for _, shard := range c.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Len возвращает суммарное количество элементов во всех шардах.
+func (c *shardedCache[K, V]) Len() int {
+	total := 0
+	for _, shard := range c.shards {
+		total += shard.Len()
+	}
+	return total
+}
+
+// Resize меняет общую ёмкость кэша, распределяя её поровну между шардами
+// с округлением вверх, как в NewShardedCache.
+func (c *shardedCache[K, V]) Resize(capacity int) {
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	shardCapacity := (capacity + len(c.shards) - 1) / len(c.shards)
+	for _, shard := range c.shards {
+		shard.Resize(shardCapacity)
+	}
+}
+
+// Clear очищает все шарды.
+// Шарды очищаются по очереди, поэтому одновременные Set могут оставить элементы в уже очищенных шардах.
+func (c *shardedCache[K, V]) Clear() {
+	for _, shard := range c.shards {
+		shard.Clear()
+	}
+}
+
+// Close останавливает фоновую очистку во всех шардах.
+func (c *shardedCache[K, V]) Close() {
+	for _, shard := range c.shards {
+		shard.Close()
+	}
+}
+
+// Stats возвращает суммарную статистику всех шардов.
+func (c *shardedCache[K, V]) Stats() Stats {
+	var total Stats
+	for _, shard := range c.shards {
+		stats := shard.Stats()
+		total.Hits += stats.Hits
+		total.Misses += stats.Misses
+		total.Evictions += stats.Evictions
+		total.Size += stats.Size
+		total.Cost += stats.Cost
+	}
+	return total
+}
+
+// Save записывает содержимое всех шардов в w; порядок элементов сохраняется в пределах шарда.
+func (c *shardedCache[K, V]) Save(w io.Writer) error {
+	var entries []snapshotEntry[K, V]
+	for _, shard := range c.shards {
+		entries = append(entries, shard.snapshot()...)
+	}
+	return writeSnapshot(c.shards[0].codec, w, entries)
+}
+
+// Load добавляет в кэш элементы, записанные Save, распределяя их по шардам.
+func (c *shardedCache[K, V]) Load(r io.Reader) error {
+	return readSnapshot(c.shards[0].codec, r, func(e snapshotEntry[K, V]) {
+		c.shard(e.Key).restore(e)
+	})
+}
@@ -0,0 +1,123 @@
+package lru
+
+import (
+	"hash/fnv"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedCache(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		c := NewShardedCache[string, int](100, 4, hashString)
+
+		require.False(t, c.Set("aaa", 100))
+		require.True(t, c.Set("aaa", 200))
+
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 200, val)
+
+		_, ok = c.Get("bbb")
+		require.False(t, ok)
+	})
+
+	t.Run("capacity", func(t *testing.T) {
+		c := NewShardedCache[string, int](16, 4, hashString)
+		for i := 0; i < 1000; i++ {
+			c.Set(strconv.Itoa(i), i)
+		}
+
+		found := 0
+		for i := 0; i < 1000; i++ {
+			if _, ok := c.Get(strconv.Itoa(i)); ok {
+				found++
+			}
+		}
+		// В каждом из 4 шардов не больше 4 элементов
+		require.LessOrEqual(t, found, 16)
+		require.Greater(t, found, 0)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		c := NewShardedCache[string, int](10, 3, hashString)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Clear()
+
+		_, ok := c.Get("a")
+		require.False(t, ok)
+		_, ok = c.Get("b")
+		require.False(t, ok)
+	})
+
+	t.Run("single shard is plain lru", func(t *testing.T) {
+		c := NewShardedCache[string, int](2, 0, hashString)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Get("a")
+		c.Set("c", 3)
+
+		_, ok := c.Get("b")
+		require.False(t, ok)
+		_, ok = c.Get("a")
+		require.True(t, ok)
+	})
+}
+
+// hashString распределяет строковые ключи по шардам.
+func hashString(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
+
+func TestShardedCacheCustomKey(t *testing.T) {
+	type point struct{ x, y int }
+
+	c := NewShardedCache[point, string](10, 4, func(p point) uint32 {
+		return uint32(p.x*31 + p.y)
+	})
+	c.Set(point{1, 2}, "a")
+
+	val, ok := c.Get(point{1, 2})
+	require.True(t, ok)
+	require.Equal(t, "a", val)
+}
+
+func TestShardedCacheStats(t *testing.T) {
+	c := NewShardedCache[string, int](4, 2, hashString)
+
+	for i := 0; i < 10; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	for i := 0; i < 10; i++ {
+		c.Get(strconv.Itoa(i))
+	}
+
+	stats := c.Stats()
+	require.Equal(t, uint64(10), stats.Hits+stats.Misses)
+	require.Equal(t, uint64(10-stats.Size), stats.Evictions)
+	require.LessOrEqual(t, stats.Size, 4)
+}
+
+func TestShardedCacheResize(t *testing.T) {
+	c := NewShardedCache[string, int](100, 4, hashString)
+	for i := 0; i < 100; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	require.LessOrEqual(t, c.Len(), 100)
+
+	c.Resize(8)
+	require.LessOrEqual(t, c.Len(), 8)
+	require.Len(t, c.Keys(), c.Len())
+
+	for _, key := range c.Keys() {
+		val, ok := c.Peek(key)
+		require.True(t, ok)
+		require.True(t, c.Delete(key))
+		require.Equal(t, key, strconv.Itoa(val))
+	}
+	require.Equal(t, 0, c.Len())
+}
@@ -0,0 +1,135 @@
+package lru
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// Encoder записывает значения в поток. Ему соответствуют *gob.Encoder и *json.Encoder.
+type Encoder interface {
+	Encode(v any) error
+}
+
+// Decoder читает значения из потока и возвращает io.EOF, когда они закончились.
+// Ему соответствуют *gob.Decoder и *json.Decoder.
+type Decoder interface {
+	Decode(v any) error
+}
+
+// Codec - формат, в котором Save и Load записывают и читают содержимое кэша.
+type Codec interface {
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+}
+
+// GobCodec записывает содержимое кэша в формате encoding/gob.
+// Конкретные типы значений, хранящихся в интерфейсах, нужно зарегистрировать через gob.Register.
+type GobCodec struct{}
+
+func (GobCodec) NewEncoder(w io.Writer) Encoder {
+	return gob.NewEncoder(w)
+}
+
+func (GobCodec) NewDecoder(r io.Reader) Decoder {
+	return gob.NewDecoder(r)
+}
+
+// JSONCodec записывает содержимое кэша в формате JSON, по одному элементу в строке.
+// Значения, хранящиеся в интерфейсах, восстанавливаются как типы по умолчанию encoding/json.
+type JSONCodec struct{}
+
+func (JSONCodec) NewEncoder(w io.Writer) Encoder {
+	return json.NewEncoder(w)
+}
+
+func (JSONCodec) NewDecoder(r io.Reader) Decoder {
+	return json.NewDecoder(r)
+}
+
+// snapshotEntry - элемент кэша в том виде, в котором его записывает Save.
+type snapshotEntry[K comparable, V any] struct {
+	Key       K
+	Value     V
+	ExpiresAt time.Time // момент истечения срока жизни (нулевой - бессрочно)
+}
+
+// Save записывает элементы с неистёкшим сроком жизни в w, начиная с того,
+// который будет вытеснен первым, вместе с моментом истечения срока жизни.
+// Содержимое кэша копируется под мьютексом, а запись в w идёт уже без него.
+func (c *cache[K, V]) Save(w io.Writer) error {
+	return writeSnapshot(c.codec, w, c.snapshot())
+}
+
+// Load добавляет в кэш элементы, записанные Save, так что порядок вытеснения
+// и моменты истечения сроков жизни сохраняются: время между Save и Load входит в срок,
+// а элементы, истёкшие к моменту Load по часам кэша, пропускаются. Элементы, не поместившиеся в ёмкость,
+// вытесняются как при обычном Set. Политики, учитывающие частоту обращений (LFU, 2Q, ARC),
+// историю обращений не восстанавливают: загруженные элементы считаются новыми.
+// При ошибке чтения элементы, прочитанные до неё, остаются в кэше.
+func (c *cache[K, V]) Load(r io.Reader) error {
+	return readSnapshot(c.codec, r, c.restore)
+}
+
+// snapshot копирует элементы с неистёкшим сроком жизни, начиная с первого кандидата на вытеснение.
+func (c *cache[K, V]) snapshot() []snapshotEntry[K, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	entries := make([]snapshotEntry[K, V], 0, len(c.items))
+	c.policy.each(func(e *entry[K, V]) {
+		if e.expired(now) {
+			return
+		}
+		entries = append(entries, snapshotEntry[K, V]{Key: e.key, Value: e.value, ExpiresAt: e.expiresAt})
+	})
+
+	// each обходит элементы от последнего кандидата на вытеснение,
+	// а Load должен добавлять их начиная с первого
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries
+}
+
+// restore добавляет в кэш элемент, прочитанный Load.
+// Элемент, срок жизни которого уже истёк, пропускается.
+func (c *cache[K, V]) restore(e snapshotEntry[K, V]) {
+	var ttl time.Duration
+	if !e.ExpiresAt.IsZero() {
+		if ttl = e.ExpiresAt.Sub(c.clock.Now()); ttl <= 0 {
+			return
+		}
+	}
+	c.SetWithTTL(e.Key, e.Value, ttl)
+}
+
+// writeSnapshot записывает элементы в w по одному.
+func writeSnapshot[K comparable, V any](codec Codec, w io.Writer, entries []snapshotEntry[K, V]) error {
+	enc := codec.NewEncoder(w)
+	for i := range entries {
+		if err := enc.Encode(&entries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSnapshot читает элементы из r до конца потока и передаёт каждый в restore.
+func readSnapshot[K comparable, V any](codec Codec, r io.Reader, restore func(snapshotEntry[K, V])) error {
+	dec := codec.NewDecoder(r)
+	for {
+		var e snapshotEntry[K, V]
+		err := dec.Decode(&e)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		restore(e)
+	}
+}
@@ -0,0 +1,126 @@
+package lru
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheSaveLoad(t *testing.T) {
+	codecs := []struct {
+		name  string
+		codec Codec
+	}{
+		{name: "gob", codec: GobCodec{}},
+		{name: "json", codec: JSONCodec{}},
+	}
+
+	for _, tc := range codecs {
+		codec := tc.codec
+		t.Run(tc.name, func(t *testing.T) {
+			clock := newFakeClock()
+			opts := []Option[string, int]{WithClock[string, int](clock), WithCodec[string, int](codec)}
+			c := NewCache(5, opts...)
+
+			c.Set("aaa", 1)
+			c.SetWithTTL("bbb", 2, time.Minute)
+			c.SetWithTTL("ccc", 3, time.Second)
+			c.Set("ddd", 4)
+			c.Get("aaa")
+			clock.Advance(time.Second) // ccc истекает и не сохраняется
+
+			var buf bytes.Buffer
+			require.NoError(t, c.Save(&buf))
+
+			restored := NewCache(5, opts...)
+			require.NoError(t, restored.Load(&buf))
+			require.Equal(t, []string{"aaa", "ddd", "bbb"}, restored.Keys())
+
+			val, ok := restored.Get("bbb")
+			require.True(t, ok)
+			require.Equal(t, 2, val)
+
+			// Сохраняется момент истечения, а не исходный срок жизни
+			clock.Advance(59 * time.Second)
+			_, ok = restored.Get("bbb")
+			require.False(t, ok)
+			_, ok = restored.Get("aaa")
+			require.True(t, ok)
+		})
+	}
+}
+
+func TestCacheLoad(t *testing.T) {
+	t.Run("smaller cache keeps most recent", func(t *testing.T) {
+		c := NewCache[string, int](10)
+		for i := 0; i < 10; i++ {
+			c.Set(strconv.Itoa(i), i)
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, c.Save(&buf))
+
+		restored := NewCache[string, int](3)
+		require.NoError(t, restored.Load(&buf))
+		require.Equal(t, []string{"9", "8", "7"}, restored.Keys())
+	})
+
+	t.Run("time between save and load counts", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(5, WithClock[string, int](clock))
+		c.Set("aaa", 1)
+		c.SetWithTTL("bbb", 2, time.Minute)
+		c.SetWithTTL("ccc", 3, 10*time.Second)
+
+		var buf bytes.Buffer
+		require.NoError(t, c.Save(&buf))
+
+		// ccc истекает, пока снимок не загружен, и не восстанавливается
+		clock.Advance(30 * time.Second)
+		restored := NewCache(5, WithClock[string, int](clock))
+		require.NoError(t, restored.Load(&buf))
+		require.Equal(t, []string{"bbb", "aaa"}, restored.Keys())
+
+		clock.Advance(30 * time.Second)
+		_, ok := restored.Get("bbb")
+		require.False(t, ok)
+		_, ok = restored.Get("aaa")
+		require.True(t, ok)
+	})
+
+	t.Run("empty snapshot", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, NewCache[string, int](3).Save(&buf))
+
+		c := NewCache[string, int](3)
+		require.NoError(t, c.Load(&buf))
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("corrupted snapshot", func(t *testing.T) {
+		c := NewCache(3, WithCodec[string, int](JSONCodec{}))
+		err := c.Load(strings.NewReader(`{"Key":"aaa","Value":1}` + "\n{broken"))
+		require.Error(t, err)
+
+		// Элементы, прочитанные до ошибки, остаются в кэше
+		require.Equal(t, []string{"aaa"}, c.Keys())
+	})
+}
+
+func TestShardedCacheSaveLoad(t *testing.T) {
+	c := NewShardedCache[string, int](100, 4, hashString)
+	for i := 0; i < 50; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, c.Save(&buf))
+
+	restored := NewShardedCache[string, int](100, 4, hashString)
+	require.NoError(t, restored.Load(&buf))
+	require.Equal(t, c.Keys(), restored.Keys())
+}
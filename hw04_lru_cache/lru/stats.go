@@ -0,0 +1,48 @@
+package lru
+
+import "fmt"
+
+// EvictReason - причина удаления элемента из кэша.
+type EvictReason int
+
+const (
+	// EvictReasonCapacity - элемент вытеснен, так как кэш заполнен.
+	EvictReasonCapacity EvictReason = iota + 1
+	// EvictReasonExpired - истёк срок жизни элемента.
+	EvictReasonExpired
+	// EvictReasonDeleted - элемент удалён явно.
+	EvictReasonDeleted
+	// EvictReasonCleared - кэш очищен методом Clear.
+	EvictReasonCleared
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictReasonCapacity:
+		return "capacity"
+	case EvictReasonExpired:
+		return "expired"
+	case EvictReasonDeleted:
+		return "deleted"
+	case EvictReasonCleared:
+		return "cleared"
+	default:
+		return fmt.Sprintf("EvictReason(%d)", int(r))
+	}
+}
+
+// Stats - статистика работы кэша.
+type Stats struct {
+	Hits      uint64 // успешные Get
+	Misses    uint64 // Get отсутствующих или истёкших элементов
+	Evictions uint64 // элементы, удалённые из-за ёмкости или истечения срока жизни
+	Size      int    // текущее количество элементов, включая истёкшие, но ещё не удалённые
+	Cost      int    // суммарная стоимость элементов (без функции стоимости совпадает с Size)
+}
+
+// eviction - удалённый элемент, о котором нужно сообщить в OnEvict.
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
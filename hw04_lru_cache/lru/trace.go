@@ -0,0 +1,46 @@
+package lru
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// TraceResult - результат воспроизведения трассы обращений к кэшу.
+type TraceResult struct {
+	Hits   int
+	Misses int
+}
+
+// HitRatio возвращает долю обращений, найденных в кэше.
+func (r TraceResult) HitRatio() float64 {
+	total := r.Hits + r.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(r.Hits) / float64(total)
+}
+
+// ReplayTrace воспроизводит трассу обращений к кэшу c: для каждой строки r берёт ключ
+// (первое поле строки, пустые строки пропускаются), читает его из кэша и при промахе добавляет.
+// Позволяет сравнить политики вытеснения на реальной нагрузке.
+func ReplayTrace(c Cache[string, struct{}], r io.Reader) (TraceResult, error) {
+	var result TraceResult
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		key := fields[0]
+		if _, ok := c.Get(key); ok {
+			result.Hits++
+			continue
+		}
+		result.Misses++
+		c.Set(key, struct{}{})
+	}
+	return result, scanner.Err()
+}
@@ -0,0 +1,21 @@
+package hw04lrucache
+
+import (
+	"hash/fnv"
+
+	"github.com/Tapler/golang-diasoft-vseroev/hw04_lru_cache/lru"
+)
+
+// NewShardedCache создаёт кэш из shards шардов с общей ёмкостью не меньше capacity.
+// Подробнее см. lru.NewShardedCache.
+func NewShardedCache(capacity, shards int, opts ...Option) Cache {
+	return lru.NewShardedCache(capacity, shards, hashKey, opts...)
+}
+
+// hashKey распределяет строковые ключи по шардам.
+func hashKey(key Key) uint32 {
+	h := fnv.New32a()
+	// Запись в hash.Hash не возвращает ошибок
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
@@ -0,0 +1,66 @@
+package hw04lrucache
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedCache(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		c := NewShardedCache(100, 4)
+
+		require.False(t, c.Set("aaa", 100))
+		require.True(t, c.Set("aaa", 200))
+
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 200, val)
+
+		_, ok = c.Get("bbb")
+		require.False(t, ok)
+	})
+
+	t.Run("capacity", func(t *testing.T) {
+		c := NewShardedCache(16, 4)
+		for i := 0; i < 1000; i++ {
+			c.Set(Key(strconv.Itoa(i)), i)
+		}
+
+		found := 0
+		for i := 0; i < 1000; i++ {
+			if _, ok := c.Get(Key(strconv.Itoa(i))); ok {
+				found++
+			}
+		}
+		// В каждом из 4 шардов не больше 4 элементов
+		require.LessOrEqual(t, found, 16)
+		require.Greater(t, found, 0)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		c := NewShardedCache(10, 3)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Clear()
+
+		_, ok := c.Get("a")
+		require.False(t, ok)
+		_, ok = c.Get("b")
+		require.False(t, ok)
+	})
+
+	t.Run("single shard is plain lru", func(t *testing.T) {
+		c := NewShardedCache(2, 0)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Get("a")
+		c.Set("c", 3)
+
+		_, ok := c.Get("b")
+		require.False(t, ok)
+		_, ok = c.Get("a")
+		require.True(t, ok)
+	})
+}
@@ -1,6 +1,6 @@
 module github.com/Tapler/golang-diasoft-vseroev/hw05_parallel_execution
 
-go 1.19
+go 1.20
 
 require (
 	github.com/stretchr/testify v1.7.0
@@ -1,8 +1,13 @@
 package hw05parallelexecution
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
 	"sync"
+	"sync/atomic"
 )
 
 var ErrErrorsLimitExceeded = errors.New("errors limit exceeded")
@@ -11,40 +16,125 @@ var ErrErrorsLimitWorkers = errors.New("number of workers must be greater than z
 
 type Task func() error
 
+// TaskError - ошибка задачи с её индексом в срезе задач.
+type TaskError struct {
+	Index int
+	Err   error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %d: %v", e.Index, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError - паника в задаче, перехваченная воркером.
+// Учитывается в лимите ошибок так же, как ошибка, возвращённая задачей.
+type PanicError struct {
+	Index int    // индекс задачи в срезе задач
+	Value any    // значение, переданное в panic
+	Stack []byte // стек горутины в момент паники
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap возвращает значение паники, если это ошибка.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// taskResult - результат выполнения задачи с индексом index.
+type taskResult struct {
+	index int
+	err   error
+}
+
+// TaskCtx - задача, которая получает контекст и должна прекращать работу при его отмене.
+type TaskCtx func(ctx context.Context) error
+
 // Run starts tasks in n goroutines and stops its work when receiving m errors from tasks.
 func Run(tasks []Task, n, m int) error {
+	ctxTasks := make([]TaskCtx, len(tasks))
+	for i, task := range tasks {
+		task := task
+		ctxTasks[i] = func(context.Context) error {
+			return task()
+		}
+	}
+	return RunContext(context.Background(), ctxTasks, n, m)
+}
+
+// RunContext выполняет задачи в n горутинах так же, как Run, и передаёт им контекст,
+// который отменяется при достижении лимита в m ошибок или при отмене ctx.
+// После остановки новые задачи не запускаются, а RunContext ждёт завершения уже запущенных.
+// Если ctx отменён раньше, чем выполнены все задачи или достигнут лимит ошибок,
+// возвращается ошибка, оборачивающая ctx.Err().
+//
+// Ошибка остановки объединяется (как errors.Join) с ошибками всех задач, завершившихся
+// с ошибкой, - каждая в виде *TaskError в порядке индексов, - поэтому errors.Is и errors.As
+// находят как ErrErrorsLimitExceeded, так и исходные ошибки задач.
+func RunContext(ctx context.Context, tasks []TaskCtx, n, m int) error {
 	if n <= 0 {
 		return ErrErrorsLimitWorkers
 	}
-	tasksCh := make(chan Task)
+	tasksCh := make(chan int) // индексы задач для воркеров
 	// WaitGroup для ожидания завершения всех воркеров
 	wg := sync.WaitGroup{}
 
 	// игнорируем ошибки если m <= 0
 	ignoreErrors := m <= 0
-	var errCount int
-	var mu sync.Mutex             // мьютекс для защиты errCount
-	stopCh := make(chan struct{}) // канал для досрочного завершения воркеров
+	var errCount int64        // увеличивается воркерами сразу после ошибки задачи
+	var doneCount int         // количество выполненных задач
+	var taskErrs []*TaskError // ошибки задач
+
+	// контекст задач: отменяется при достижении лимита ошибок или отмене ctx
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var stopErr error // причина досрочной остановки
 	var stopOnce sync.Once
+	stop := func(err error) {
+		stopOnce.Do(func() {
+			stopErr = err
+			cancel()
+		})
+	}
 
 	// буферизованный канал для сбора ошибок от воркеров
 	// размер буфера = количество воркеров, чтобы избежать блокировок
-	errCh := make(chan error, n)
+	errCh := make(chan taskResult, n)
 
 	// воркер выполняет задачи из tasksCh
 	worker := func() {
 		defer wg.Done() // уменьшает счетчик на 1, когда воркер завершает работу
 		for {
+			// select выбирает готовую ветку случайно, поэтому остановку проверяем отдельно,
+			// чтобы не взять новую задачу после достижения лимита
+			if runCtx.Err() != nil {
+				return
+			}
+
 			select {
-			case <-stopCh:
+			case <-runCtx.Done():
 				// Получен сигнал остановки — выходим
 				return
-			case task, ok := <-tasksCh:
+			case index, ok := <-tasksCh:
 				if !ok {
 					// Задачи закончились — выходим
 					return
 				}
-				errCh <- task() // Выполняем задачу и отправляем ошибку или nil
+				// Выполняем задачу и отправляем ошибку или nil
+				err := runTask(runCtx, index, tasks[index])
+				// Ошибку считаем до отправки в errCh: пока она лежит в буфере,
+				// воркеры не должны запускать новые задачи сверх лимита
+				if err != nil && !ignoreErrors && atomic.AddInt64(&errCount, 1) >= int64(m) {
+					stop(limitError(ctx))
+				}
+				errCh <- taskResult{index: index, err: err}
 			}
 		}
 	}
@@ -57,12 +147,12 @@ func Run(tasks []Task, n, m int) error {
 
 	// Горутина для отправки задач в канал
 	go func() {
-		for _, task := range tasks {
+		for index := range tasks {
 			select {
-			case <-stopCh:
+			case <-runCtx.Done():
 				// Если получен сигнал остановки — прекращаем отправку задач
 				return
-			case tasksCh <- task:
+			case tasksCh <- index:
 			}
 		}
 		close(tasksCh) // Все задачи отправлены — закрываем канал
@@ -76,25 +166,62 @@ func Run(tasks []Task, n, m int) error {
 
 	// собираем ошибки от воркеров в цикле
 	// цикл завершится когда errCh будет закрыт и все ошибки обработаны
-	for err := range errCh {
-		if err != nil && !ignoreErrors {
-			mu.Lock()
-			errCount++
-			// Досрочное завершение если достигли лимита m
-			if errCount >= m {
-				stopOnce.Do(func() { close(stopCh) })
-			}
-			mu.Unlock()
+	for result := range errCh {
+		doneCount++
+		if result.err != nil && !ignoreErrors {
+			taskErrs = append(taskErrs, &TaskError{Index: result.index, Err: result.err})
 		}
 	}
 
-	// Проверяем превышен ли лимит ошибок с защитой от race condition
-	mu.Lock()
-	shouldReturnError := !ignoreErrors && errCount >= m
-	mu.Unlock()
+	// ctx мог быть отменён и после выполнения всех задач - это не ошибка
+	if ctxErr := ctx.Err(); stopErr == nil && ctxErr != nil && doneCount < len(tasks) {
+		stopErr = cancelledError(ctxErr)
+	}
+	if stopErr == nil {
+		return nil
+	}
+	return joinTaskErrors(stopErr, taskErrs)
+}
 
-	if shouldReturnError {
-		return ErrErrorsLimitExceeded
+// runTask выполняет задачу и превращает её панику в *PanicError,
+// чтобы паника одной задачи не завершала весь процесс.
+// Панику определяем по тому, что задача не вернулась: до Go 1.21 recover возвращает nil для panic(nil).
+func runTask(ctx context.Context, index int, task TaskCtx) (err error) {
+	completed := false
+	defer func() {
+		if !completed {
+			err = &PanicError{Index: index, Value: recover(), Stack: debug.Stack()}
+		}
+	}()
+	err = task(ctx)
+	completed = true
+	return err
+}
+
+// joinTaskErrors объединяет причину остановки с ошибками задач, упорядоченными по индексу.
+func joinTaskErrors(stopErr error, taskErrs []*TaskError) error {
+	sort.Slice(taskErrs, func(i, j int) bool {
+		return taskErrs[i].Index < taskErrs[j].Index
+	})
+
+	errs := make([]error, 0, len(taskErrs)+1)
+	errs = append(errs, stopErr)
+	for _, err := range taskErrs {
+		errs = append(errs, err)
 	}
-	return nil
+	return errors.Join(errs...)
+}
+
+// limitError возвращает причину остановки при достижении лимита ошибок.
+// Если ctx уже отменён, ошибки задач, скорее всего, вызваны отменой.
+func limitError(ctx context.Context) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return cancelledError(ctxErr)
+	}
+	return ErrErrorsLimitExceeded
+}
+
+// cancelledError оборачивает ошибку отменённого контекста.
+func cancelledError(err error) error {
+	return fmt.Errorf("run interrupted: %w", err)
 }
@@ -1,8 +1,10 @@
 package hw05parallelexecution
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"sync/atomic"
 	"testing"
@@ -140,3 +142,211 @@ func TestRun(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestRunContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	// blockingTasks создаёт задачи, которые ждут отмены контекста и считают запущенные.
+	blockingTasks := func(count int, started *int32) []TaskCtx {
+		tasks := make([]TaskCtx, 0, count)
+		for i := 0; i < count; i++ {
+			tasks = append(tasks, func(ctx context.Context) error {
+				atomic.AddInt32(started, 1)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		}
+		return tasks
+	}
+
+	t.Run("parent cancellation stops the run", func(t *testing.T) {
+		var started int32
+		tasks := blockingTasks(50, &started)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		err := RunContext(ctx, tasks, 5, 0)
+		require.ErrorIs(t, err, context.Canceled)
+		require.LessOrEqual(t, atomic.LoadInt32(&started), int32(10), "extra tasks were started")
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		var started int32
+		tasks := blockingTasks(50, &started)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// Отменённые задачи возвращают ошибки, но причиной остаётся истёкший срок
+		err := RunContext(ctx, tasks, 5, 1)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.False(t, errors.Is(err, ErrErrorsLimitExceeded))
+	})
+
+	t.Run("errors limit cancels running tasks", func(t *testing.T) {
+		var cancelled int32
+		tasks := []TaskCtx{
+			func(context.Context) error {
+				time.Sleep(10 * time.Millisecond)
+				return errors.New("failed")
+			},
+		}
+		for i := 0; i < 3; i++ {
+			tasks = append(tasks, func(ctx context.Context) error {
+				<-ctx.Done()
+				atomic.AddInt32(&cancelled, 1)
+				return nil
+			})
+		}
+
+		err := RunContext(context.Background(), tasks, 4, 1)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.Equal(t, int32(3), atomic.LoadInt32(&cancelled))
+	})
+
+	t.Run("all tasks done", func(t *testing.T) {
+		var runTasksCount int32
+		tasks := make([]TaskCtx, 0, 20)
+		for i := 0; i < 20; i++ {
+			tasks = append(tasks, func(ctx context.Context) error {
+				require.NoError(t, ctx.Err())
+				atomic.AddInt32(&runTasksCount, 1)
+				return nil
+			})
+		}
+
+		err := RunContext(context.Background(), tasks, 4, 1)
+		require.NoError(t, err)
+		require.Equal(t, int32(20), runTasksCount)
+	})
+}
+
+func TestRunErrors(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	t.Run("limit exceeded wraps task errors", func(t *testing.T) {
+		tasks := []Task{
+			func() error { return nil },
+			func() error { return errFirst },
+			func() error { return nil },
+			func() error { return errSecond },
+		}
+
+		err := Run(tasks, 1, 2)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.ErrorIs(t, err, errFirst)
+		require.ErrorIs(t, err, errSecond)
+
+		var taskErr *TaskError
+		require.ErrorAs(t, err, &taskErr)
+		require.Equal(t, 1, taskErr.Index)
+		require.Equal(t, "errors limit exceeded\ntask 1: first\ntask 3: second", err.Error())
+
+		multi, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint
+		require.True(t, ok)
+		require.Len(t, multi.Unwrap(), 3)
+	})
+
+	t.Run("errors below limit are not reported", func(t *testing.T) {
+		tasks := []Task{
+			func() error { return errFirst },
+			func() error { return nil },
+		}
+
+		require.NoError(t, Run(tasks, 2, 2))
+	})
+
+	t.Run("limit larger than int32", func(t *testing.T) {
+		limit := int64(math.MaxInt32) + 2
+		tasks := []Task{
+			func() error { return errFirst },
+			func() error { return errSecond },
+		}
+
+		require.NoError(t, Run(tasks, 2, int(limit)))
+	})
+
+	t.Run("cancellation wraps task errors", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		tasks := []TaskCtx{
+			func(context.Context) error {
+				cancel()
+				return errFirst
+			},
+			func(context.Context) error { return nil },
+		}
+
+		err := RunContext(ctx, tasks, 1, 5)
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, errFirst)
+		require.False(t, errors.Is(err, ErrErrorsLimitExceeded))
+	})
+}
+
+func TestRunPanics(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	t.Run("panic is reported as error", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		tasks := []Task{
+			func() error { return nil },
+			func() error { panic("oops") },
+			func() error { panic(errBoom) },
+		}
+
+		err := Run(tasks, 2, 2)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.ErrorIs(t, err, errBoom)
+
+		var panicErr *PanicError
+		require.ErrorAs(t, err, &panicErr)
+		require.Equal(t, 1, panicErr.Index)
+		require.Equal(t, "oops", panicErr.Value)
+		require.Contains(t, string(panicErr.Stack), "TestRunPanics")
+		require.Contains(t, err.Error(), "task 1: panic: oops")
+	})
+
+	t.Run("panic with nil value", func(t *testing.T) {
+		tasks := []Task{
+			func() error { panic(nil) },
+		}
+
+		err := Run(tasks, 1, 1)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+		var panicErr *PanicError
+		require.ErrorAs(t, err, &panicErr)
+		require.Equal(t, 0, panicErr.Index)
+	})
+
+	t.Run("panics count towards limit", func(t *testing.T) {
+		var runTasksCount int32
+		tasks := make([]Task, 0, 20)
+		for i := 0; i < 20; i++ {
+			tasks = append(tasks, func() error {
+				atomic.AddInt32(&runTasksCount, 1)
+				panic("fail")
+			})
+		}
+
+		err := Run(tasks, 2, 3)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.LessOrEqual(t, runTasksCount, int32(2+3))
+	})
+
+	t.Run("panics below limit are ignored", func(t *testing.T) {
+		tasks := []Task{
+			func() error { panic("fail") },
+			func() error { return nil },
+		}
+
+		require.NoError(t, Run(tasks, 1, 2))
+		require.NoError(t, Run(tasks, 1, 0))
+	})
+}